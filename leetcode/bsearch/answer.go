@@ -0,0 +1,58 @@
+package bsearch
+
+import (
+	"math"
+
+	"leetcode/constraints"
+)
+
+// FirstTrue 二分答案: 在[lo, hi)中找第一个使pred为true的值，都为false时返回hi
+// pred必须单调，即前面一段为false，后面一段为true
+func FirstTrue[T constraints.Integer](lo, hi T, pred func(T) bool) T {
+	for lo < hi {
+		// 用无符号数算区间长度，lo和hi取到类型的极值也不会溢出
+		mid := lo + T((uint64(hi)-uint64(lo))/2)
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// LastTrue 在[lo, hi)中找最后一个使pred为true的值，pred前面一段为true后面一段为false
+// 都为false时返回false
+func LastTrue[T constraints.Integer](lo, hi T, pred func(T) bool) (T, bool) {
+	i := FirstTrue(lo, hi, func(x T) bool { return !pred(x) })
+	if i == lo {
+		return lo, false
+	}
+	return i - 1, true
+}
+
+// FirstTrueFloat 浮点数上的二分答案，返回满足pred的近似最小值，误差不超过eps
+// 在[lo, hi]内都不满足时返回hi
+func FirstTrueFloat(lo, hi, eps float64, pred func(float64) bool) float64 {
+	// 最多迭代到浮点数精度耗尽
+	for i := 0; i < 2000 && hi-lo > eps; i++ {
+		mid := lo + (hi-lo)/2
+		if mid <= lo || mid >= hi {
+			break
+		}
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
+
+// Sqrt 整数平方根，FirstTrue的一个例子
+func Sqrt(x uint64) uint64 {
+	r, _ := LastTrue(uint64(0), min(x, math.MaxUint32)+1, func(m uint64) bool {
+		return m*m <= x
+	})
+	return r
+}
@@ -0,0 +1,48 @@
+package bsearch
+
+import "cmp"
+
+// LowerBound 第一个大于等于x的下标，都小于x时返回len(s)
+func LowerBound[T cmp.Ordered](s []T, x T) int {
+	return LowerBoundFunc(s, x, cmp.Compare[T])
+}
+
+// UpperBound 第一个大于x的下标，都不大于x时返回len(s)
+func UpperBound[T cmp.Ordered](s []T, x T) int {
+	return UpperBoundFunc(s, x, cmp.Compare[T])
+}
+
+// EqualRange 等于x的元素所在的区间[lo, hi)，不存在时lo == hi，为x应插入的位置
+func EqualRange[T cmp.Ordered](s []T, x T) (int, int) {
+	return LowerBound(s, x), UpperBound(s, x)
+}
+
+// Search 返回等于x的某个下标，不存在返回-1
+func Search[T cmp.Ordered](s []T, x T) int {
+	l, r := 0, len(s)-1
+	for l <= r {
+		mid := l + (r-l)/2
+		if s[mid] == x {
+			return mid
+		}
+		if s[mid] > x {
+			r = mid - 1
+		} else {
+			l = mid + 1
+		}
+	}
+	return -1
+}
+
+// LowerBoundFunc 与slices.BinarySearchFunc相同，cmp(e, x)比较元素与目标
+func LowerBoundFunc[E, T any](s []E, x T, cmp func(E, T) int) int {
+	return FirstTrue(0, len(s), func(i int) bool {
+		return cmp(s[i], x) >= 0
+	})
+}
+
+func UpperBoundFunc[E, T any](s []E, x T, cmp func(E, T) int) int {
+	return FirstTrue(0, len(s), func(i int) bool {
+		return cmp(s[i], x) > 0
+	})
+}
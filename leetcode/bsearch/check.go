@@ -0,0 +1,113 @@
+package bsearch
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// sortedArrays 值域[0, m)内长度不超过n的所有非降序数组
+func sortedArrays(n, m int) [][]int {
+	res := [][]int{{}}
+	var dfs func(cur []int, from int)
+	dfs = func(cur []int, from int) {
+		if len(cur) == n {
+			return
+		}
+		for v := from; v < m; v++ {
+			next := append(slices.Clone(cur), v)
+			res = append(res, next)
+			dfs(next, v)
+		}
+	}
+	dfs(nil, 0)
+	return res
+}
+
+// 所有长度<=7、值域[0,4)的有序数组上与线性扫描对比
+func TestBsearch() {
+	bad := map[string]int{}
+	for _, s := range sortedArrays(7, 4) {
+		for x := -1; x <= 4; x++ {
+			lower, upper := len(s), len(s)
+			for i := len(s) - 1; i >= 0; i-- {
+				if s[i] >= x {
+					lower = i
+				}
+				if s[i] > x {
+					upper = i
+				}
+			}
+			if LowerBound(s, x) != lower {
+				bad["LowerBound"]++
+			}
+			if UpperBound(s, x) != upper {
+				bad["UpperBound"]++
+			}
+			if lo, hi := EqualRange(s, x); lo != lower || hi != upper {
+				bad["EqualRange"]++
+			}
+			if i := Search(s, x); (lower == upper && i != -1) || (lower < upper && (i < lower || i >= upper)) {
+				bad["Search"]++
+			}
+		}
+	}
+
+	// 旋转数组: 长度<=9的所有旋转
+	for n := 0; n <= 9; n++ {
+		base := make([]int, n)
+		for i := range base {
+			base[i] = 2 * i
+		}
+		for k := 0; k < max(1, n); k++ {
+			s := append(slices.Clone(base[min(k, n):]), base[:min(k, n)]...)
+			if n > 0 && s[RotationPoint(s)] != 0 {
+				bad["RotationPoint"]++
+			}
+			for x := -1; x <= 2*n; x++ {
+				if SearchRotated(s, x) != slices.Index(s, x) {
+					bad["SearchRotated"]++
+				}
+			}
+		}
+	}
+
+	// 二分答案: int8上所有区间和阈值，包括类型的极值
+	for lo := math.MinInt8; lo <= math.MaxInt8; lo += 3 {
+		for hi := lo; hi <= math.MaxInt8; hi += 5 {
+			for _, t := range []int{math.MinInt8, -1, 0, 1, lo, hi, math.MaxInt8} {
+				want := hi
+				for x := lo; x < hi; x++ {
+					if x >= t {
+						want = x
+						break
+					}
+				}
+				got := FirstTrue(int8(lo), int8(hi), func(x int8) bool { return int(x) >= t })
+				if int(got) != want {
+					bad["FirstTrue"]++
+				}
+				last, ok := LastTrue(int8(lo), int8(hi), func(x int8) bool { return int(x) < t })
+				if wantOK := want > lo; ok != wantOK || (ok && int(last) != want-1) {
+					bad["LastTrue"]++
+				}
+			}
+		}
+	}
+	if FirstTrue(uint64(0), math.MaxUint64, func(x uint64) bool { return x >= math.MaxUint64-1 }) != math.MaxUint64-1 {
+		bad["FirstTrue-uint64"]++
+	}
+	if FirstTrue(math.MinInt64, math.MaxInt64, func(x int64) bool { return x >= -7 }) != -7 {
+		bad["FirstTrue-int64"]++
+	}
+	for _, x := range []uint64{0, 1, 2, 3, 4, 15, 16, 17, 1 << 40, math.MaxUint64} {
+		r := Sqrt(x)
+		if r*r > x || (r+1 <= math.MaxUint32 && (r+1)*(r+1) <= x) {
+			bad["Sqrt"]++
+		}
+	}
+	if v := FirstTrueFloat(0, 10, 1e-9, func(x float64) bool { return x*x >= 2 }); math.Abs(v-math.Sqrt2) > 1e-9 {
+		bad["FirstTrueFloat"]++
+	}
+	fmt.Println("mismatches:", bad)
+}
@@ -0,0 +1,31 @@
+package bsearch
+
+import "cmp"
+
+// RotationPoint 旋转后的升序数组中最小元素的下标，元素互不相同，空数组返回0
+// 最小值之后的元素都不大于末尾元素，之前的都大于末尾元素
+func RotationPoint[T cmp.Ordered](s []T) int {
+	if len(s) == 0 {
+		return 0
+	}
+	last := s[len(s)-1]
+	return FirstTrue(0, len(s), func(i int) bool { return s[i] <= last })
+}
+
+// SearchRotated 在旋转后的升序数组中查找x，元素互不相同，不存在返回-1
+// 先找到旋转点，再在x所在的那一段有序区间上二分
+func SearchRotated[T cmp.Ordered](s []T, x T) int {
+	if len(s) == 0 {
+		return -1
+	}
+	k := RotationPoint(s)
+	lo, hi := 0, k
+	if x <= s[len(s)-1] {
+		lo, hi = k, len(s)
+	}
+	i := lo + LowerBound(s[lo:hi], x)
+	if i < hi && s[i] == x {
+		return i
+	}
+	return -1
+}
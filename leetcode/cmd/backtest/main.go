@@ -0,0 +1,96 @@
+// backtest 读取日线价格CSV，在给定限制下求事后最优的买卖点，并与买入持有比较
+//
+//	backtest -csv prices.csv -k 2 -cooldown 1 -fee 0.5
+//
+// CSV第一行为表头，默认读取Date列和Close列
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"leetcode/stock"
+)
+
+func main() {
+	path := flag.String("csv", "", "价格文件")
+	dateCol := flag.String("date", "Date", "日期列名")
+	priceCol := flag.String("price", "Close", "价格列名")
+	k := flag.Int("k", 0, "最多交易次数，0表示不限")
+	cooldown := flag.Int("cooldown", 0, "卖出后的冷却天数")
+	fee := flag.Float64("fee", 0, "每次交易的手续费")
+	flag.Parse()
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dates, prices, err := load(*path, *dateCol, *priceCol)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	c := stock.Constraints[float64]{MaxTransactions: *k, Cooldown: *cooldown, Fee: *fee}
+	res := stock.Optimize(prices, c)
+	for i, t := range res.Trades {
+		gain := prices[t.Sell] - prices[t.Buy] - c.Fee
+		fmt.Printf("%3d  buy %s @ %.2f  sell %s @ %.2f  %+.2f\n",
+			i+1, dates[t.Buy], prices[t.Buy], dates[t.Sell], prices[t.Sell], gain)
+	}
+	fmt.Printf("days=%d trades=%d profit=%.2f\n", len(prices), len(res.Trades), res.Profit)
+	if len(prices) > 1 {
+		hold := prices[len(prices)-1] - prices[0] - c.Fee
+		fmt.Printf("buy and hold %s -> %s: %.2f\n", dates[0], dates[len(dates)-1], hold)
+	}
+}
+
+// load 读取日期列和价格列，跳过价格为空或无法解析的行(如停牌)
+func load(path, dateCol, priceCol string) ([]string, []float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	find := func(name string) int {
+		return slices.IndexFunc(header, func(h string) bool {
+			return strings.EqualFold(strings.TrimSpace(h), name)
+		})
+	}
+	di, pi := find(dateCol), find(priceCol)
+	if di < 0 || pi < 0 {
+		return nil, nil, fmt.Errorf("%s: columns %q and %q are required, header is %v", path, dateCol, priceCol, header)
+	}
+	var dates []string
+	var prices []float64
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if max(di, pi) >= len(rec) {
+			continue
+		}
+		p, err := strconv.ParseFloat(strings.TrimSpace(rec[pi]), 64)
+		if err != nil {
+			continue
+		}
+		dates = append(dates, rec[di])
+		prices = append(prices, p)
+	}
+	return dates, prices, nil
+}
@@ -0,0 +1,72 @@
+// extsort 对以换行分隔的大文件排序，内存不够时借助临时文件做外部归并排序
+//
+//	extsort -in big.txt -out sorted.txt -mem 256
+//	cat big.txt | extsort -r > sorted.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"leetcode/extsort"
+)
+
+func main() {
+	in := flag.String("in", "", "输入文件，默认标准输入")
+	out := flag.String("out", "", "输出文件，默认标准输出，可以与输入相同")
+	mem := flag.Int64("mem", 64, "内存预算(MB)")
+	tmp := flag.String("tmp", "", "临时文件目录")
+	fanIn := flag.Int("fanin", 64, "一次最多归并的顺串数")
+	reverse := flag.Bool("r", false, "逆序")
+	verbose := flag.Bool("v", false, "在标准错误输出统计信息")
+	flag.Parse()
+
+	opt := extsort.Options{MemoryBudget: *mem << 20, TempDir: *tmp, MaxFanIn: *fanIn}
+	if *reverse {
+		opt.Compare = func(a, b string) int { return strings.Compare(b, a) }
+	}
+
+	var stats extsort.Stats
+	var err error
+	if *in != "" && *out != "" {
+		stats, err = extsort.SortFile(*in, *out, opt)
+	} else {
+		var r io.Reader = os.Stdin
+		var w io.Writer = os.Stdout
+		if *in != "" {
+			f, e := os.Open(*in)
+			if e != nil {
+				fmt.Fprintln(os.Stderr, e)
+				os.Exit(1)
+			}
+			defer f.Close()
+			r = f
+		}
+		var dst *os.File
+		if *out != "" {
+			f, e := os.Create(*out)
+			if e != nil {
+				fmt.Fprintln(os.Stderr, e)
+				os.Exit(1)
+			}
+			dst, w = f, f
+		}
+		stats, err = extsort.Sort(r, w, opt)
+		// 写入失败可能到Close时才报告
+		if dst != nil {
+			if cerr := dst.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *verbose {
+		fmt.Fprintf(os.Stderr, "records=%d runs=%d passes=%d\n", stats.Records, stats.Runs, stats.Passes)
+	}
+}
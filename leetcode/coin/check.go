@@ -0,0 +1,135 @@
+package coin
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+)
+
+// brute 枚举每种面值用几枚，返回最少枚数(-1表示凑不出)和组合数
+func brute(coins []Coin, amount int) (int, int) {
+	best, ways := -1, 0
+	var dfs func(k, rest, used int)
+	dfs = func(k, rest, used int) {
+		if k == len(coins) {
+			if rest == 0 {
+				ways++
+				if best < 0 || used < best {
+					best = used
+				}
+			}
+			return
+		}
+		for n := 0; n*coins[k].Value <= rest && (coins[k].Count <= 0 || n <= coins[k].Count); n++ {
+			dfs(k+1, rest-n*coins[k].Value, used+n)
+		}
+	}
+	dfs(0, amount, 0)
+	return best, ways
+}
+
+// brutePermutations 枚举最后一枚硬币
+func brutePermutations(values []int, amount int) int {
+	if amount == 0 {
+		return 1
+	}
+	n := 0
+	for _, v := range values {
+		if v <= amount {
+			n += brutePermutations(values, amount-v)
+		}
+	}
+	return n
+}
+
+// valid 检查硬币之和为amount，且每种面值不超过可用数量
+func valid(used []int, coins []Coin, amount int) bool {
+	sum := 0
+	count := map[int]int{}
+	for _, v := range used {
+		sum += v
+		count[v]++
+	}
+	limit := map[int]int{}
+	for _, c := range coins {
+		if c.Count <= 0 {
+			limit[c.Value] = -1
+		} else if limit[c.Value] >= 0 {
+			limit[c.Value] += c.Count
+		}
+	}
+	for v, n := range count {
+		if l, ok := limit[v]; !ok || (l >= 0 && n > l) {
+			return false
+		}
+	}
+	return sum == amount
+}
+
+func TestCoin() {
+	r := rand.New(rand.NewSource(50))
+	bad := map[string]int{}
+	for round := 0; round < 500; round++ {
+		var values []int
+		for k := 1 + r.Intn(4); k > 0; k-- {
+			v := 1 + r.Intn(9)
+			if !contains(values, v) {
+				values = append(values, v)
+			}
+		}
+		amount := r.Intn(30)
+		bounded := make([]Coin, len(values))
+		for i, v := range values {
+			bounded[i] = Coin{Value: v, Count: r.Intn(4)} // 0表示不限
+		}
+
+		wantMin, wantWays := brute(Unlimited(values), amount)
+		used, ok := MinCoins(values, amount)
+		if ok != (wantMin >= 0) || (ok && (len(used) != wantMin || !valid(used, Unlimited(values), amount))) {
+			bad["MinCoins"]++
+		}
+		if n, ok := Combinations(values, amount); !ok || n != wantWays || CombinationsBig(values, amount).Int64() != int64(wantWays) {
+			bad["Combinations"]++
+		}
+		if amount <= 20 {
+			want := brutePermutations(values, amount)
+			if n, ok := Permutations(values, amount); !ok || n != want || PermutationsBig(values, amount).Int64() != int64(want) {
+				bad["Permutations"]++
+			}
+		}
+
+		wantMin, wantWays = brute(bounded, amount)
+		used, ok = MinCoinsBounded(bounded, amount)
+		if ok != (wantMin >= 0) || (ok && (len(used) != wantMin || !valid(used, bounded, amount))) {
+			bad["MinCoinsBounded"]++
+		}
+		if n, ok := CombinationsBounded(bounded, amount); !ok || n != wantWays {
+			bad["CombinationsBounded"]++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+
+	fmt.Println(MinCoins([]int{1, 2, 5}, 11))
+	fmt.Println(MinCoinsBounded([]Coin{{Value: 1, Count: 3}, {Value: 5, Count: 1}, {Value: 4, Count: 3}}, 12))
+	fmt.Println(Combinations([]int{1, 2, 5}, 5))
+	// 排列数是斐波那契数，amount较大时溢出int64
+	n, ok := Permutations([]int{1, 2}, 100)
+	fmt.Println(n, ok, PermutationsBig([]int{1, 2}, 100))
+	want := new(big.Int)
+	a, b := big.NewInt(1), big.NewInt(1)
+	for i := 2; i <= 100; i++ {
+		a, b = b, new(big.Int).Add(a, b)
+	}
+	want.Set(b)
+	fmt.Println(want.Cmp(PermutationsBig([]int{1, 2}, 100)) == 0)
+	fmt.Println(CombinationsBig([]int{1, 2, 5, 10, 20, 50, 100, 200}, 10000))
+}
+
+func contains(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package coin
+
+import (
+	"math"
+	"math/big"
+	"slices"
+)
+
+// Coin 面值及可用数量，Count <= 0 表示不限数量
+type Coin struct {
+	Value int
+	Count int
+}
+
+// Unlimited 每种面值都不限数量
+func Unlimited(values []int) []Coin {
+	coins := make([]Coin, len(values))
+	for i, v := range values {
+		coins[i] = Coin{Value: v}
+	}
+	return coins
+}
+
+// MinCoins 凑出amount所需的最少硬币，返回用到的每一枚硬币(从大到小)，凑不出返回false
+// dp[i]为凑出i的最少枚数，last[i]记录最后一枚的面值用于回溯
+func MinCoins(values []int, amount int) ([]int, bool) {
+	if amount < 0 {
+		return nil, false
+	}
+	const inf = math.MaxInt
+	dp := make([]int, amount+1)
+	last := make([]int, amount+1)
+	for i := 1; i <= amount; i++ {
+		dp[i] = inf
+		for _, v := range values {
+			if v > 0 && v <= i && dp[i-v] != inf && dp[i-v]+1 < dp[i] {
+				dp[i], last[i] = dp[i-v]+1, v
+			}
+		}
+	}
+	if dp[amount] == inf {
+		return nil, false
+	}
+	res := make([]int, 0, dp[amount])
+	for i := amount; i > 0; i -= last[i] {
+		res = append(res, last[i])
+	}
+	slices.SortFunc(res, func(a, b int) int { return b - a })
+	return res, true
+}
+
+// item 二进制拆分后的一组硬币: k枚面值为value的硬币，当作一件物品
+type item struct {
+	value, k int
+}
+
+// split 将数量为c的硬币拆成1,2,4,...,剩余 若干组，任意0..c枚都能由其中若干组凑出
+func split(c Coin, amount int) []item {
+	count := c.Count
+	if count <= 0 || count > amount/c.Value {
+		count = amount / c.Value // 不限数量时最多用amount/value枚
+	}
+	var items []item
+	for k := 1; count > 0; k <<= 1 {
+		k = min(k, count)
+		items = append(items, item{value: c.Value, k: k})
+		count -= k
+	}
+	return items
+}
+
+// MinCoinsBounded 每种面值数量有限时的最少硬币
+// 二进制拆分成0/1背包，O(amount * Σlog(count))，chosen记录每件物品是否被选中用于回溯
+func MinCoinsBounded(coins []Coin, amount int) ([]int, bool) {
+	if amount < 0 {
+		return nil, false
+	}
+	var items []item
+	for _, c := range coins {
+		if c.Value > 0 {
+			items = append(items, split(c, amount)...)
+		}
+	}
+	const inf = math.MaxInt
+	dp := make([]int, amount+1)
+	for i := 1; i <= amount; i++ {
+		dp[i] = inf
+	}
+	chosen := make([][]bool, len(items))
+	for n, it := range items {
+		chosen[n] = make([]bool, amount+1)
+		w := it.value * it.k
+		for i := amount; i >= w; i-- {
+			if dp[i-w] != inf && dp[i-w]+it.k < dp[i] {
+				dp[i] = dp[i-w] + it.k
+				chosen[n][i] = true
+			}
+		}
+	}
+	if dp[amount] == inf {
+		return nil, false
+	}
+	var res []int
+	for n, i := len(items)-1, amount; n >= 0; n-- {
+		if chosen[n][i] {
+			for range items[n].k {
+				res = append(res, items[n].value)
+			}
+			i -= items[n].value * items[n].k
+		}
+	}
+	slices.SortFunc(res, func(a, b int) int { return b - a })
+	return res, true
+}
+
+// add 带溢出检测的加法，-1表示已经溢出
+func add(a, b int) int {
+	if a < 0 || b < 0 || a > math.MaxInt-b {
+		return -1
+	}
+	return a + b
+}
+
+// Combinations 凑出amount的组合数(518)，不同顺序算同一种；溢出int时返回false
+// 外层遍历面值、内层遍历金额，每种组合只按面值顺序计一次
+func Combinations(values []int, amount int) (int, bool) {
+	if amount < 0 {
+		return 0, true
+	}
+	dp := make([]int, amount+1)
+	dp[0] = 1
+	for _, v := range values {
+		if v <= 0 {
+			continue
+		}
+		for i := v; i <= amount; i++ {
+			dp[i] = add(dp[i], dp[i-v])
+		}
+	}
+	return max(dp[amount], 0), dp[amount] >= 0
+}
+
+// Permutations 凑出amount的排列数(377)，不同顺序算不同的方案；溢出int时返回false
+// 外层遍历金额、内层遍历面值，相当于枚举最后一枚硬币
+func Permutations(values []int, amount int) (int, bool) {
+	if amount < 0 {
+		return 0, true
+	}
+	dp := make([]int, amount+1)
+	dp[0] = 1
+	for i := 1; i <= amount; i++ {
+		for _, v := range values {
+			if v > 0 && v <= i {
+				dp[i] = add(dp[i], dp[i-v])
+			}
+		}
+	}
+	return max(dp[amount], 0), dp[amount] >= 0
+}
+
+// CombinationsBig 与Combinations相同，结果用big.Int表示不会溢出
+func CombinationsBig(values []int, amount int) *big.Int {
+	return CombinationsBoundedBig(Unlimited(values), amount)
+}
+
+// PermutationsBig 与Permutations相同，结果用big.Int表示
+func PermutationsBig(values []int, amount int) *big.Int {
+	if amount < 0 {
+		return new(big.Int)
+	}
+	dp := make([]*big.Int, amount+1)
+	dp[0] = big.NewInt(1)
+	for i := 1; i <= amount; i++ {
+		dp[i] = new(big.Int)
+		for _, v := range values {
+			if v > 0 && v <= i {
+				dp[i].Add(dp[i], dp[i-v])
+			}
+		}
+	}
+	return dp[amount]
+}
+
+// CombinationsBounded 每种面值数量有限时的组合数，溢出int时返回false
+func CombinationsBounded(coins []Coin, amount int) (int, bool) {
+	n := CombinationsBoundedBig(coins, amount)
+	if !n.IsInt64() || n.Int64() > math.MaxInt {
+		return 0, false
+	}
+	return int(n.Int64()), true
+}
+
+// CombinationsBoundedBig 数量有限的组合数: 加入一种面值v(最多c枚)后
+// next[i] = dp[i] + dp[i-v] + ... + dp[i-c*v]，按i mod v分组用滑动窗口求和，每种面值O(amount)
+func CombinationsBoundedBig(coins []Coin, amount int) *big.Int {
+	if amount < 0 {
+		return new(big.Int)
+	}
+	dp := make([]*big.Int, amount+1)
+	for i := range dp {
+		dp[i] = new(big.Int)
+	}
+	dp[0].SetInt64(1)
+	for _, c := range coins {
+		if c.Value <= 0 {
+			continue
+		}
+		v := c.Value
+		next := make([]*big.Int, amount+1)
+		for r := 0; r < v && r <= amount; r++ {
+			window := new(big.Int)
+			for i, k := r, 0; i <= amount; i, k = i+v, k+1 {
+				window.Add(window, dp[i])
+				// 窗口内最多c+1项: dp[i], dp[i-v], ..., dp[i-c*v]
+				if c.Count > 0 && k > c.Count {
+					window.Sub(window, dp[i-(c.Count+1)*v])
+				}
+				next[i] = new(big.Int).Set(window)
+			}
+		}
+		dp = next
+	}
+	return dp[amount]
+}
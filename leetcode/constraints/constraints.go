@@ -0,0 +1,32 @@
+// Package constraints 各个包共用的泛型类型约束
+package constraints
+
+// Signed 有符号整数
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned 无符号整数
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer 所有整数类型
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float 浮点数
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number 可以求和与比较大小的数值类型
+type Number interface {
+	Integer | Float
+}
+
+// Real 可以取负数的数值类型，例如收益可能为负
+type Real interface {
+	Signed | Float
+}
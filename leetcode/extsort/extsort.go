@@ -0,0 +1,210 @@
+package extsort
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Options 外部排序的参数，零值表示使用默认值
+type Options struct {
+	MemoryBudget int64                 // 内存中一批记录占用的字节上限，默认64MB
+	TempDir      string                // 临时文件目录，默认os.TempDir()
+	MaxFanIn     int                   // 一次最多同时归并的顺串数，超过时分多趟归并，默认64
+	Compare      func(a, b string) int // 记录的比较函数，默认按字节序
+}
+
+// Stats 一次排序的统计
+type Stats struct {
+	Records int64 // 记录条数
+	Runs    int   // 第一趟生成的顺串数，0表示全部在内存中完成
+	Passes  int   // 归并的趟数
+}
+
+// recordOverhead 每条记录除内容外的估算开销(字符串头和切片元素)
+const recordOverhead = 32
+
+func (o Options) withDefaults() Options {
+	if o.MemoryBudget <= 0 {
+		o.MemoryBudget = 64 << 20
+	}
+	if o.MaxFanIn < 2 {
+		o.MaxFanIn = 64
+	}
+	if o.Compare == nil {
+		o.Compare = strings.Compare
+	}
+	return o
+}
+
+// Sort 读取以换行分隔的记录，排序后写入w，每条记录以换行结尾
+// 内存放不下时把排好序的一批记录写入临时文件作为顺串，最后用小根堆多路归并
+// 相等的记录保持输入中的先后顺序
+func Sort(r io.Reader, w io.Writer, opt Options) (Stats, error) {
+	opt = opt.withDefaults()
+	var stats Stats
+	var runs []string
+	defer func() {
+		for _, name := range runs {
+			os.Remove(name)
+		}
+	}()
+
+	br := bufio.NewReaderSize(r, 1<<16)
+	var batch []string
+	var used int64
+	for {
+		line, err := br.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(line, "\n")
+			batch = append(batch, line)
+			used += int64(len(line)) + recordOverhead
+			stats.Records++
+			if used >= opt.MemoryBudget {
+				name, err := spill(batch, opt)
+				if err != nil {
+					return stats, err
+				}
+				runs = append(runs, name)
+				batch, used = batch[:0], 0
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+	}
+
+	bw := bufio.NewWriterSize(w, 1<<16)
+	// 全部在内存中，不需要临时文件
+	if len(runs) == 0 {
+		slices.SortStableFunc(batch, opt.Compare)
+		if err := writeLines(bw, batch); err != nil {
+			return stats, err
+		}
+		return stats, bw.Flush()
+	}
+	if len(batch) > 0 {
+		name, err := spill(batch, opt)
+		if err != nil {
+			return stats, err
+		}
+		runs = append(runs, name)
+	}
+	batch = nil
+	stats.Runs = len(runs)
+
+	// 顺串过多时先分组归并成较少的顺串，顺串按生成顺序分组，保证稳定
+	for len(runs) > opt.MaxFanIn {
+		stats.Passes++
+		var next []string
+		for i := 0; i < len(runs); i += opt.MaxFanIn {
+			group := runs[i:min(i+opt.MaxFanIn, len(runs))]
+			name, err := mergeToTemp(group, opt)
+			if err != nil {
+				runs = append(runs, next...)
+				return stats, err
+			}
+			next = append(next, name)
+			for _, old := range group {
+				os.Remove(old)
+			}
+		}
+		runs = next
+	}
+	stats.Passes++
+	if err := mergeRuns(runs, bw, opt.Compare); err != nil {
+		return stats, err
+	}
+	return stats, bw.Flush()
+}
+
+// SortFile 对文件排序，out可以与in相同
+func SortFile(in, out string, opt Options) (Stats, error) {
+	src, err := os.Open(in)
+	if err != nil {
+		return Stats{}, err
+	}
+	defer src.Close()
+	// 先写到同目录下的临时文件，成功后再改名
+	dst, err := os.CreateTemp(filepath.Dir(out), ".extsort-out-*")
+	if err != nil {
+		return Stats{}, err
+	}
+	stats, err := Sort(src, dst, opt)
+	// CreateTemp创建的文件权限为0600，改成与输入相同
+	if err == nil {
+		var info os.FileInfo
+		if info, err = src.Stat(); err == nil {
+			err = dst.Chmod(info.Mode().Perm())
+		}
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(dst.Name(), out)
+	}
+	if err != nil {
+		os.Remove(dst.Name())
+	}
+	return stats, err
+}
+
+// spill 将一批记录排序后写入临时文件，返回文件名
+func spill(batch []string, opt Options) (string, error) {
+	slices.SortStableFunc(batch, opt.Compare)
+	f, err := os.CreateTemp(opt.TempDir, "extsort-run-*")
+	if err != nil {
+		return "", err
+	}
+	bw := bufio.NewWriterSize(f, 1<<16)
+	err = writeLines(bw, batch)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// mergeToTemp 将一组顺串归并成一个新的临时顺串
+func mergeToTemp(group []string, opt Options) (string, error) {
+	f, err := os.CreateTemp(opt.TempDir, "extsort-run-*")
+	if err != nil {
+		return "", err
+	}
+	bw := bufio.NewWriterSize(f, 1<<16)
+	err = mergeRuns(group, bw, opt.Compare)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func writeLines(bw *bufio.Writer, lines []string) error {
+	for _, line := range lines {
+		bw.WriteString(line)
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
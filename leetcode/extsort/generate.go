@@ -0,0 +1,140 @@
+package extsort
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Generate 生成约size字节的随机记录写入w，每行是长度不一的小写字母串，用于测试
+func Generate(w io.Writer, size int64, seed int64) (int64, error) {
+	r := rand.New(rand.NewSource(seed))
+	bw := bufio.NewWriterSize(w, 1<<16)
+	var written, records int64
+	line := make([]byte, 0, 128)
+	for written < size {
+		line = line[:0]
+		for k := 8 + r.Intn(64); k > 0; k-- {
+			line = append(line, byte('a'+r.Intn(26)))
+		}
+		line = append(line, '\n')
+		if _, err := bw.Write(line); err != nil {
+			return records, err
+		}
+		written += int64(len(line))
+		records++
+	}
+	return records, bw.Flush()
+}
+
+// digest 统计记录数和与顺序无关的校验和，同时检查是否有序
+func digest(r io.Reader) (records int64, sum uint64, sorted bool, err error) {
+	br := bufio.NewReaderSize(r, 1<<16)
+	sorted = true
+	prev, first := "", true
+	for {
+		line, e := br.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(line, "\n")
+			h := fnv.New64a()
+			h.Write([]byte(line))
+			sum += h.Sum64()
+			records++
+			if !first && line < prev {
+				sorted = false
+			}
+			prev, first = line, false
+		}
+		if e == io.EOF {
+			return records, sum, sorted, nil
+		}
+		if e != nil {
+			return records, sum, sorted, e
+		}
+	}
+}
+
+func digestFile(name string) (int64, uint64, bool, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	defer f.Close()
+	return digest(f)
+}
+
+// testSizeMB 大文件测试的输入大小，默认4MB；
+// 测几百MB的输入时设置环境变量，例如 EXTSORT_TEST_MB=300
+func testSizeMB() int64 {
+	if v, err := strconv.ParseInt(os.Getenv("EXTSORT_TEST_MB"), 10, 64); err == nil && v > 0 {
+		return v
+	}
+	return 4
+}
+
+// 生成大文件后在只有其几分之一的内存预算下排序，检查输出有序且与输入是同一组记录
+func TestExtSort() {
+	// 小输入: 空输入、没有结尾换行、重复记录、稳定性
+	for _, tc := range []struct{ in, want string }{
+		{"", ""},
+		{"b\na\nc", "a\nb\nc\n"},
+		{"x\n\nx\na\n", "\na\nx\nx\n"},
+	} {
+		var sb strings.Builder
+		for _, budget := range []int64{1, 1 << 20} {
+			sb.Reset()
+			_, err := Sort(strings.NewReader(tc.in), &sb, Options{MemoryBudget: budget, MaxFanIn: 2})
+			fmt.Printf("%q budget=%d -> %v %v\n", tc.in, budget, sb.String() == tc.want, err)
+		}
+	}
+	byKey := func(a, b string) int { return strings.Compare(a[:1], b[:1]) }
+	var sb strings.Builder
+	Sort(strings.NewReader("b1\na1\nb2\na2\nb3\na3\n"), &sb, Options{MemoryBudget: 40, MaxFanIn: 2, Compare: byKey})
+	fmt.Println("stable:", sb.String() == "a1\na2\na3\nb1\nb2\nb3\n")
+
+	testExtSort(testSizeMB() << 20)
+}
+
+func testExtSort(size int64) {
+	dir, err := os.MkdirTemp("", "extsort-test-*")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	in := filepath.Join(dir, "input.txt")
+	f, err := os.Create(in)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	start := time.Now()
+	records, err := Generate(f, size, 39)
+	f.Close()
+	fmt.Printf("generated %d MB, %d records in %v, err=%v\n", size>>20, records, time.Since(start), err)
+	wantRecords, wantSum, _, _ := digestFile(in)
+
+	for _, opt := range []Options{
+		{MemoryBudget: size / 8, TempDir: dir},
+		{MemoryBudget: size / 32, TempDir: dir, MaxFanIn: 8}, // 多趟归并
+	} {
+		out := filepath.Join(dir, "output.txt")
+		start := time.Now()
+		stats, err := SortFile(in, out, opt)
+		elapsed := time.Since(start)
+		gotRecords, gotSum, sorted, _ := digestFile(out)
+		fmt.Printf("budget=%dKB fanin=%d runs=%d passes=%d time=%v sorted=%v same=%v err=%v\n",
+			opt.MemoryBudget>>10, opt.MaxFanIn, stats.Runs, stats.Passes, elapsed,
+			sorted, gotRecords == wantRecords && gotSum == wantSum, err)
+	}
+	// 临时顺串应已全部删除
+	left, _ := filepath.Glob(filepath.Join(dir, "extsort-run-*"))
+	fmt.Println("leftover runs:", len(left))
+}
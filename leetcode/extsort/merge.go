@@ -0,0 +1,104 @@
+package extsort
+
+import (
+	"bufio"
+	"container/heap"
+	"io"
+	"os"
+	"strings"
+)
+
+// run 一个已排序的顺串文件，cur为当前未输出的记录
+type run struct {
+	f     *os.File
+	r     *bufio.Reader
+	cur   string
+	index int // 顺串的生成顺序，记录相等时先输出靠前的顺串
+}
+
+// next 读取下一条记录，读完返回false
+func (r *run) next() (bool, error) {
+	line, err := r.r.ReadString('\n')
+	if len(line) > 0 {
+		r.cur = strings.TrimSuffix(line, "\n")
+		return true, nil
+	}
+	if err == io.EOF {
+		return false, nil
+	}
+	return false, err
+}
+
+// runHeap 与mergeKLists中的queue相同，按每个顺串的当前记录组成小根堆
+type runHeap struct {
+	item    []*run
+	compare func(a, b string) int
+}
+
+func (q *runHeap) Len() int {
+	return len(q.item)
+}
+func (q *runHeap) Less(i, j int) bool {
+	c := q.compare(q.item[i].cur, q.item[j].cur)
+	if c != 0 {
+		return c < 0
+	}
+	return q.item[i].index < q.item[j].index
+}
+func (q *runHeap) Swap(i, j int) {
+	q.item[i], q.item[j] = q.item[j], q.item[i]
+}
+func (q *runHeap) Pop() interface{} {
+	v := q.item[q.Len()-1]
+	q.item = q.item[:q.Len()-1]
+	return v
+}
+func (q *runHeap) Push(x interface{}) {
+	q.item = append(q.item, x.(*run))
+}
+
+// mergeRuns 多路归并若干顺串文件写入bw
+func mergeRuns(names []string, bw *bufio.Writer, compare func(a, b string) int) error {
+	q := &runHeap{compare: compare}
+	defer func() {
+		for _, r := range q.item {
+			r.f.Close()
+		}
+	}()
+	for i, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		r := &run{f: f, r: bufio.NewReaderSize(f, 1<<16), index: i}
+		ok, err := r.next()
+		if err != nil || !ok {
+			f.Close()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		q.item = append(q.item, r)
+	}
+	heap.Init(q)
+	for q.Len() > 0 {
+		// 堆顶输出后原地读取下一条并下沉，比Pop再Push少一次调整
+		top := q.item[0]
+		bw.WriteString(top.cur)
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+		ok, err := top.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(q, 0)
+		} else {
+			heap.Pop(q)
+			top.f.Close()
+		}
+	}
+	return nil
+}
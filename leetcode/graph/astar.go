@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"container/heap"
+)
+
+// Point 网格中的坐标
+type Point struct {
+	R, C int
+}
+
+// Heuristic 估计从a到b的剩余代价，不高估真实代价时A*得到最优解
+type Heuristic func(a, b Point) int
+
+// Manhattan 四连通网格且每格代价不小于1时可采纳
+func Manhattan(a, b Point) int {
+	return abs(a.R-b.R) + abs(a.C-b.C)
+}
+
+// Chebyshev 比Manhattan更宽松的下界
+func Chebyshev(a, b Point) int {
+	return max(abs(a.R-b.R), abs(a.C-b.C))
+}
+
+// ZeroHeuristic 退化为Dijkstra
+func ZeroHeuristic(a, b Point) int {
+	return 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+type openItem struct {
+	p Point
+	f int
+	g int
+}
+
+type openSet []openItem
+
+func (o openSet) Len() int { return len(o) }
+func (o openSet) Less(i, j int) bool {
+	if o[i].f == o[j].f {
+		return o[i].g > o[j].g
+	}
+	return o[i].f < o[j].f
+}
+func (o openSet) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+func (o *openSet) Push(x interface{}) {
+	*o = append(*o, x.(openItem))
+}
+func (o *openSet) Pop() interface{} {
+	v := (*o)[len(*o)-1]
+	*o = (*o)[:len(*o)-1]
+	return v
+}
+
+// AStar 四连通网格上的A*搜索，cost[r][c]为进入该格的代价，<=0表示障碍
+// 返回路径(含起点终点)和总代价，不可达返回 false
+func AStar(cost [][]int, start, goal Point, h Heuristic) ([]Point, int, bool) {
+	if len(cost) == 0 || len(cost[0]) == 0 {
+		return nil, 0, false
+	}
+	m, n := len(cost), len(cost[0])
+	in := func(p Point) bool {
+		return p.R >= 0 && p.R < m && p.C >= 0 && p.C < n && cost[p.R][p.C] > 0
+	}
+	if !in(start) || !in(goal) {
+		return nil, 0, false
+	}
+	dist := make([]int, m*n)
+	prev := make([]int, m*n)
+	for i := range dist {
+		dist[i] = Inf
+		prev[i] = -1
+	}
+	id := func(p Point) int { return p.R*n + p.C }
+	dist[id(start)] = 0
+	open := &openSet{{p: start, f: h(start, goal), g: 0}}
+	dir := [][]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(openItem)
+		if cur.g > dist[id(cur.p)] {
+			continue // 过期的节点
+		}
+		if cur.p == goal {
+			break
+		}
+		for _, d := range dir {
+			next := Point{cur.p.R + d[0], cur.p.C + d[1]}
+			if !in(next) {
+				continue
+			}
+			if g := cur.g + cost[next.R][next.C]; g < dist[id(next)] {
+				dist[id(next)] = g
+				prev[id(next)] = id(cur.p)
+				heap.Push(open, openItem{p: next, f: g + h(next, goal), g: g})
+			}
+		}
+	}
+	if dist[id(goal)] == Inf {
+		return nil, 0, false
+	}
+	path := []Point{}
+	for x := id(goal); x != -1; x = prev[x] {
+		path = append(path, Point{x / n, x % n})
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, dist[id(goal)], true
+}
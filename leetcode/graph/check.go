@@ -0,0 +1,250 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// randomGraph 生成n个顶点m条边的随机图，边权在[minW, maxW]之间，用于自测
+func randomGraph(r *rand.Rand, n, m, minW, maxW int, directed bool) *Graph {
+	g := New(n)
+	if !directed {
+		g = NewUndirected(n)
+	}
+	for i := 0; i < m; i++ {
+		g.AddWeightedEdge(r.Intn(n), r.Intn(n), minW+r.Intn(maxW-minW+1))
+	}
+	return g
+}
+
+// pathWeight 按路径上相邻顶点间的最小边权求和，路径不合法返回-1
+func pathWeight(g *Graph, path []int) int {
+	total := 0
+	for i := 0; i+1 < len(path); i++ {
+		best := Inf
+		for _, e := range g.adj[path[i]] {
+			if e.To == path[i+1] {
+				best = min(best, e.Weight)
+			}
+		}
+		if best == Inf {
+			return -1
+		}
+		total += best
+	}
+	return total
+}
+
+func TestShortestPath() {
+	r := rand.New(rand.NewSource(1))
+	bad := 0
+	for round := 0; round < 200; round++ {
+		n := 1 + r.Intn(30)
+		g := randomGraph(r, n, r.Intn(4*n), 0, 20, round%2 == 0)
+		src := r.Intn(n)
+		dj := Dijkstra(g, src)
+		bf, cycle := BellmanFord(g, src)
+		fw := FloydWarshall(g)
+		if cycle != nil {
+			bad++
+		}
+		for v := 0; v < n; v++ {
+			if dj.Dist[v] != bf.Dist[v] || dj.Dist[v] != fw.Dist[src][v] {
+				bad++
+				continue
+			}
+			if dj.Dist[v] == Inf {
+				continue
+			}
+			if pathWeight(g, dj.PathTo(v)) != dj.Dist[v] ||
+				pathWeight(g, bf.PathTo(v)) != dj.Dist[v] ||
+				pathWeight(g, fw.Path(src, v)) != dj.Dist[v] {
+				bad++
+			}
+		}
+
+		// 0-1 图
+		g01 := randomGraph(r, n, r.Intn(4*n), 0, 1, true)
+		zo, dj01 := ZeroOneBFS(g01, src), Dijkstra(g01, src)
+		for v := 0; v < n; v++ {
+			if zo.Dist[v] != dj01.Dist[v] || (zo.Dist[v] != Inf && pathWeight(g01, zo.PathTo(v)) != zo.Dist[v]) {
+				bad++
+			}
+		}
+
+		// 网格上A*与Dijkstra比较
+		rows, cols := 1+r.Intn(8), 1+r.Intn(8)
+		cost := make([][]int, rows)
+		grid := New(rows * cols)
+		for i := range cost {
+			cost[i] = make([]int, cols)
+			for j := range cost[i] {
+				if r.Intn(5) > 0 {
+					cost[i][j] = 1 + r.Intn(9)
+				}
+			}
+		}
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				for _, d := range [][]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+					x, y := i+d[0], j+d[1]
+					if x >= 0 && x < rows && y >= 0 && y < cols && cost[i][j] > 0 && cost[x][y] > 0 {
+						grid.AddWeightedEdge(i*cols+j, x*cols+y, cost[x][y])
+					}
+				}
+			}
+		}
+		start, goal := Point{r.Intn(rows), r.Intn(cols)}, Point{r.Intn(rows), r.Intn(cols)}
+		want := Dijkstra(grid, start.R*cols+start.C).Dist[goal.R*cols+goal.C]
+		for _, h := range []Heuristic{Manhattan, Chebyshev, ZeroHeuristic} {
+			_, got, ok := AStar(cost, start, goal, h)
+			if cost[start.R][start.C] <= 0 || cost[goal.R][goal.C] <= 0 {
+				if ok {
+					bad++
+				}
+				continue
+			}
+			if (!ok && want != Inf) || (ok && got != want) {
+				bad++
+			}
+		}
+	}
+	fmt.Println("random rounds mismatches:", bad)
+
+	// 负环
+	g := New(4)
+	g.AddWeightedEdge(0, 1, 1)
+	g.AddWeightedEdge(1, 2, -2)
+	g.AddWeightedEdge(2, 3, -2)
+	g.AddWeightedEdge(3, 1, 1)
+	_, cycle := BellmanFord(g, 0)
+	fmt.Println(cycle, pathWeight(g, cycle), FloydWarshall(g).HasNegativeCycle())
+
+	path, c, ok := AStar([][]int{{1, 1, 1}, {0, 0, 1}, {1, 1, 1}}, Point{0, 0}, Point{2, 0}, Manhattan)
+	fmt.Println(path, c, ok)
+}
+
+// componentIDs 将分量列表转换为每个顶点的分量编号
+func componentIDs(n int, comps [][]int) []int {
+	id := make([]int, n)
+	for i, c := range comps {
+		for _, v := range c {
+			id[v] = i
+		}
+	}
+	return id
+}
+
+// without 返回删除指定边或顶点后的无向图，vertex为-1表示不删除顶点
+func without(g *Graph, edgeID, vertex int) *Graph {
+	h := NewUndirected(g.n)
+	for _, e := range g.edges {
+		if e.ID == edgeID || e.From == vertex || e.To == vertex {
+			continue
+		}
+		h.AddWeightedEdge(e.From, e.To, e.Weight)
+	}
+	return h
+}
+
+// bipartiteBrute 枚举所有染色，每条边两端颜色不同
+func bipartiteBrute(g *Graph) bool {
+	for mask := 0; mask < 1<<g.n; mask++ {
+		ok := true
+		for _, e := range g.edges {
+			if mask>>e.From&1 == mask>>e.To&1 {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConnectivity() {
+	r := rand.New(rand.NewSource(2))
+	bad := 0
+	for round := 0; round < 300; round++ {
+		n := 1 + r.Intn(12)
+		g := randomGraph(r, n, r.Intn(2*n), 1, 10, false)
+		_, count := ConnectedComponents(g)
+
+		kw, ke := Kruskal(g)
+		pw, pe, _ := Prim(g)
+		if kw != pw || len(ke) != n-count || len(pe) != n-count {
+			bad++
+		}
+
+		// 暴力验证桥和割点
+		isBridge := map[int]bool{}
+		bridges, _ := Bridges(g)
+		for _, e := range bridges {
+			isBridge[e.ID] = true
+		}
+		for _, e := range g.edges {
+			_, c := ConnectedComponents(without(g, e.ID, -1))
+			if (c > count) != isBridge[e.ID] {
+				bad++
+			}
+		}
+		isCut := map[int]bool{}
+		cuts, _ := ArticulationPoints(g)
+		for _, v := range cuts {
+			isCut[v] = true
+		}
+		for v := 0; v < n; v++ {
+			// 删除顶点后自身成为孤立点，需要减掉
+			_, c := ConnectedComponents(without(g, -1, v))
+			if (c-1 > count) != isCut[v] {
+				bad++
+			}
+		}
+
+		// 暴力枚举染色验证二分图，有向图忽略方向
+		d := randomGraph(r, n, r.Intn(3*n), 1, 1, true)
+		for _, h := range []*Graph{g, d} {
+			if _, ok := Bipartite(h); ok != bipartiteBrute(h) {
+				bad++
+			}
+		}
+		// 只支持无向图的算法拒绝有向图
+		_, _, errPrim := Prim(d)
+		_, errBridges := Bridges(d)
+		_, errCuts := ArticulationPoints(d)
+		if errPrim != ErrDirected || errBridges != ErrDirected || errCuts != ErrDirected {
+			bad++
+		}
+
+		// 有向图的强连通分量与可达性比较
+		ap := FloydWarshall(d)
+		t := componentIDs(n, SCCTarjan(d))
+		k := componentIDs(n, SCCKosaraju(d))
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				same := ap.Dist[u][v] != Inf && ap.Dist[v][u] != Inf
+				if (t[u] == t[v]) != same || (k[u] == k[v]) != same {
+					bad++
+				}
+			}
+		}
+	}
+	fmt.Println("random rounds mismatches:", bad)
+
+	g := NewUndirected(5)
+	g.AddWeightedEdge(0, 1, 1)
+	g.AddWeightedEdge(1, 2, 2)
+	g.AddWeightedEdge(2, 0, 3)
+	g.AddWeightedEdge(1, 3, 4)
+	g.AddWeightedEdge(3, 4, 5)
+	fmt.Println(Kruskal(g))
+	fmt.Println(Bridges(g))
+	fmt.Println(ArticulationPoints(g))
+	// 1->0, 2->0 忽略方向后是二分图
+	d := New(3)
+	d.AddEdge(1, 0)
+	d.AddEdge(2, 0)
+	fmt.Println(Bipartite(d))
+}
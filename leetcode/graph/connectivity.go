@@ -0,0 +1,217 @@
+package graph
+
+import "leetcode/structure"
+
+// ConnectedComponents 无向图的连通分量，返回每个顶点所属分量编号和分量个数
+func ConnectedComponents(g *Graph) ([]int, int) {
+	vertices := make([]int, g.n)
+	for i := range vertices {
+		vertices[i] = i
+	}
+	us := structure.NewUnionSet(vertices)
+	for _, e := range g.edges {
+		us.Union(e.From, e.To)
+	}
+	comp := make([]int, g.n)
+	ids := map[int]int{}
+	for v := 0; v < g.n; v++ {
+		root := us.Find(v)
+		if _, ok := ids[root]; !ok {
+			ids[root] = len(ids)
+		}
+		comp[v] = ids[root]
+	}
+	return comp, len(ids)
+}
+
+// lowLink tarjan算法在无向图上的dfs，dfn为访问时间戳，low为不经过父边能回到的最早时间戳
+func lowLink(g *Graph, visit func(u int, children int, isRoot bool, cut bool), bridge func(e Edge)) {
+	dfn := make([]int, g.n)
+	low := make([]int, g.n)
+	timer := 0
+	var dfs func(u, parentEdge int, isRoot bool)
+	dfs = func(u, parentEdge int, isRoot bool) {
+		timer++
+		dfn[u], low[u] = timer, timer
+		children := 0
+		cut := false
+		for _, e := range g.adj[u] {
+			if e.ID == parentEdge {
+				continue
+			}
+			if dfn[e.To] == 0 {
+				children++
+				dfs(e.To, e.ID, false)
+				low[u] = min(low[u], low[e.To])
+				if low[e.To] > dfn[u] {
+					bridge(e)
+				}
+				if low[e.To] >= dfn[u] {
+					cut = true
+				}
+			} else {
+				low[u] = min(low[u], dfn[e.To])
+			}
+		}
+		visit(u, children, isRoot, cut)
+	}
+	for v := 0; v < g.n; v++ {
+		if dfn[v] == 0 {
+			dfs(v, -1, true)
+		}
+	}
+}
+
+// Bridges 无向图中删除后使连通分量增加的边，按边ID去掉父边因此支持重边，有向图返回ErrDirected
+func Bridges(g *Graph) ([]Edge, error) {
+	if g.directed {
+		return nil, ErrDirected
+	}
+	var res []Edge
+	lowLink(g, func(int, int, bool, bool) {}, func(e Edge) {
+		res = append(res, e)
+	})
+	return res, nil
+}
+
+// ArticulationPoints 无向图的割点
+// 根节点有两个及以上的dfs子树时是割点，非根节点存在子节点low >= dfn时是割点，有向图返回ErrDirected
+func ArticulationPoints(g *Graph) ([]int, error) {
+	if g.directed {
+		return nil, ErrDirected
+	}
+	var res []int
+	lowLink(g, func(u int, children int, isRoot bool, cut bool) {
+		if (isRoot && children >= 2) || (!isRoot && cut) {
+			res = append(res, u)
+		}
+	}, func(Edge) {})
+	return res, nil
+}
+
+// SCCTarjan 有向图的强连通分量，low[u] == dfn[u]时栈中u以上的顶点构成一个分量
+func SCCTarjan(g *Graph) [][]int {
+	dfn := make([]int, g.n)
+	low := make([]int, g.n)
+	onStack := make([]bool, g.n)
+	stack := []int{}
+	timer := 0
+	var res [][]int
+	var dfs func(u int)
+	dfs = func(u int) {
+		timer++
+		dfn[u], low[u] = timer, timer
+		stack = append(stack, u)
+		onStack[u] = true
+		for _, e := range g.adj[u] {
+			if dfn[e.To] == 0 {
+				dfs(e.To)
+				low[u] = min(low[u], low[e.To])
+			} else if onStack[e.To] {
+				low[u] = min(low[u], dfn[e.To])
+			}
+		}
+		if low[u] == dfn[u] {
+			comp := []int{}
+			for {
+				v := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[v] = false
+				comp = append(comp, v)
+				if v == u {
+					break
+				}
+			}
+			res = append(res, comp)
+		}
+	}
+	for v := 0; v < g.n; v++ {
+		if dfn[v] == 0 {
+			dfs(v)
+		}
+	}
+	return res
+}
+
+// SCCKosaraju 先在原图上求后序，再按后序逆序在反图上dfs，每棵树是一个强连通分量
+func SCCKosaraju(g *Graph) [][]int {
+	visited := make([]bool, g.n)
+	order := make([]int, 0, g.n)
+	var dfs1 func(u int)
+	dfs1 = func(u int) {
+		visited[u] = true
+		for _, e := range g.adj[u] {
+			if !visited[e.To] {
+				dfs1(e.To)
+			}
+		}
+		order = append(order, u)
+	}
+	for v := 0; v < g.n; v++ {
+		if !visited[v] {
+			dfs1(v)
+		}
+	}
+
+	r := g.Reverse()
+	for i := range visited {
+		visited[i] = false
+	}
+	var res [][]int
+	var comp []int
+	var dfs2 func(u int)
+	dfs2 = func(u int) {
+		visited[u] = true
+		comp = append(comp, u)
+		for _, e := range r.adj[u] {
+			if !visited[e.To] {
+				dfs2(e.To)
+			}
+		}
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		if !visited[order[i]] {
+			comp = []int{}
+			dfs2(order[i])
+			res = append(res, comp)
+		}
+	}
+	return res
+}
+
+// Bipartite bfs染色判断二分图，返回每个顶点的颜色0/1
+// 有向图忽略边的方向，沿出边和入边同时染色
+func Bipartite(g *Graph) ([]int, bool) {
+	adj := g.adj
+	if g.directed {
+		adj = make([][]Edge, g.n)
+		for _, e := range g.edges {
+			adj[e.From] = append(adj[e.From], e)
+			adj[e.To] = append(adj[e.To], Edge{ID: e.ID, From: e.To, To: e.From, Weight: e.Weight})
+		}
+	}
+	color := make([]int, g.n)
+	for i := range color {
+		color[i] = -1
+	}
+	for s := 0; s < g.n; s++ {
+		if color[s] != -1 {
+			continue
+		}
+		color[s] = 0
+		queue := []int{s}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, e := range adj[u] {
+				if color[e.To] == -1 {
+					color[e.To] = 1 - color[u]
+					queue = append(queue, e.To)
+				} else if color[e.To] == color[u] {
+					return nil, false
+				}
+			}
+		}
+	}
+	return color, true
+}
@@ -0,0 +1,91 @@
+package graph
+
+import "errors"
+
+// ErrDirected 只支持无向图的算法传入了有向图
+var ErrDirected = errors.New("graph: undirected graph required")
+
+// Edge 表示一条边，无向图中同一条边的两个方向共享同一个ID
+type Edge struct {
+	ID     int
+	From   int
+	To     int
+	Weight int
+}
+
+// Graph 邻接表表示的图，顶点编号为 0..n-1
+type Graph struct {
+	n        int
+	directed bool
+	adj      [][]Edge
+	edges    []Edge
+}
+
+// New 创建有n个顶点的有向图
+func New(n int) *Graph {
+	return &Graph{n: n, directed: true, adj: make([][]Edge, n)}
+}
+
+// NewUndirected 创建有n个顶点的无向图
+func NewUndirected(n int) *Graph {
+	return &Graph{n: n, directed: false, adj: make([][]Edge, n)}
+}
+
+// FromPrerequisites 按课程表的格式建图，[a, b] 表示先修b才能修a，即边 b -> a
+func FromPrerequisites(numCourses int, prerequisites [][]int) *Graph {
+	g := New(numCourses)
+	for _, p := range prerequisites {
+		g.AddEdge(p[1], p[0])
+	}
+	return g
+}
+
+func (g *Graph) N() int {
+	return g.n
+}
+
+func (g *Graph) Directed() bool {
+	return g.directed
+}
+
+// AddEdge 添加权重为1的边
+func (g *Graph) AddEdge(u, v int) {
+	g.AddWeightedEdge(u, v, 1)
+}
+
+func (g *Graph) AddWeightedEdge(u, v, w int) {
+	e := Edge{ID: len(g.edges), From: u, To: v, Weight: w}
+	g.edges = append(g.edges, e)
+	g.adj[u] = append(g.adj[u], e)
+	if !g.directed {
+		g.adj[v] = append(g.adj[v], Edge{ID: e.ID, From: v, To: u, Weight: w})
+	}
+}
+
+// Neighbors 返回从u出发的所有边
+func (g *Graph) Neighbors(u int) []Edge {
+	return g.adj[u]
+}
+
+// Edges 返回所有边，无向边只出现一次
+func (g *Graph) Edges() []Edge {
+	return g.edges
+}
+
+// InDegrees 统计每个顶点的入度，仅对有向图有意义
+func (g *Graph) InDegrees() []int {
+	in := make([]int, g.n)
+	for _, e := range g.edges {
+		in[e.To]++
+	}
+	return in
+}
+
+// Reverse 返回所有边反向后的有向图
+func (g *Graph) Reverse() *Graph {
+	r := New(g.n)
+	for _, e := range g.edges {
+		r.AddWeightedEdge(e.To, e.From, e.Weight)
+	}
+	return r
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"container/heap"
+	"sort"
+
+	"leetcode/structure"
+)
+
+// Kruskal 按边权从小到大加边，用并查集判断是否成环
+// 图不连通时返回最小生成森林
+func Kruskal(g *Graph) (int, []Edge) {
+	edges := append([]Edge(nil), g.edges...)
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Weight < edges[j].Weight
+	})
+	vertices := make([]int, g.n)
+	for i := range vertices {
+		vertices[i] = i
+	}
+	us := structure.NewUnionSet(vertices)
+	var total int
+	var res []Edge
+	for _, e := range edges {
+		if us.IsSameSet(e.From, e.To) {
+			continue
+		}
+		us.Union(e.From, e.To)
+		total += e.Weight
+		res = append(res, e)
+	}
+	return total, res
+}
+
+type edgeHeap []Edge
+
+func (h edgeHeap) Len() int           { return len(h) }
+func (h edgeHeap) Less(i, j int) bool { return h[i].Weight < h[j].Weight }
+func (h edgeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *edgeHeap) Push(x interface{}) {
+	*h = append(*h, x.(Edge))
+}
+func (h *edgeHeap) Pop() interface{} {
+	v := (*h)[len(*h)-1]
+	*h = (*h)[:len(*h)-1]
+	return v
+}
+
+// Prim 从每个未访问的顶点出发，不断选取连接树内外的最小边
+// 图不连通时返回最小生成森林，只沿出边扩展，有向图返回ErrDirected
+func Prim(g *Graph) (int, []Edge, error) {
+	if g.directed {
+		return 0, nil, ErrDirected
+	}
+	visited := make([]bool, g.n)
+	var total int
+	var res []Edge
+	for s := 0; s < g.n; s++ {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		h := &edgeHeap{}
+		for _, e := range g.adj[s] {
+			heap.Push(h, e)
+		}
+		for h.Len() > 0 {
+			e := heap.Pop(h).(Edge)
+			if visited[e.To] {
+				continue
+			}
+			visited[e.To] = true
+			total += e.Weight
+			res = append(res, e)
+			for _, next := range g.adj[e.To] {
+				if !visited[next.To] {
+					heap.Push(h, next)
+				}
+			}
+		}
+	}
+	return total, res, nil
+}
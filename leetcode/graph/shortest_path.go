@@ -0,0 +1,282 @@
+package graph
+
+import "math"
+
+// Inf 表示不可达
+const Inf = math.MaxInt
+
+// ShortestPaths 单源最短路的结果
+type ShortestPaths struct {
+	Source int
+	Dist   []int // Dist[v]为源点到v的距离，不可达为Inf
+	Prev   []int // Prev[v]为最短路上v的前驱，没有为-1
+}
+
+func newShortestPaths(n, src int) *ShortestPaths {
+	sp := &ShortestPaths{Source: src, Dist: make([]int, n), Prev: make([]int, n)}
+	for i := 0; i < n; i++ {
+		sp.Dist[i] = Inf
+		sp.Prev[i] = -1
+	}
+	sp.Dist[src] = 0
+	return sp
+}
+
+// PathTo 返回从源点到v的路径，不可达返回nil
+func (sp *ShortestPaths) PathTo(v int) []int {
+	if sp.Dist[v] == Inf {
+		return nil
+	}
+	path := []int{}
+	for x := v; x != -1; x = sp.Prev[x] {
+		path = append(path, x)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// indexHeap 支持decrease-key的小根堆，pos[v]记录顶点v在堆中的下标，不在堆中为-1
+type indexHeap struct {
+	item []int
+	pos  []int
+	key  []int
+}
+
+func newIndexHeap(n int, key []int) *indexHeap {
+	h := &indexHeap{pos: make([]int, n), key: key}
+	for i := range h.pos {
+		h.pos[i] = -1
+	}
+	return h
+}
+
+func (h *indexHeap) Len() int {
+	return len(h.item)
+}
+
+func (h *indexHeap) swap(i, j int) {
+	h.item[i], h.item[j] = h.item[j], h.item[i]
+	h.pos[h.item[i]] = i
+	h.pos[h.item[j]] = j
+}
+
+func (h *indexHeap) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if h.key[h.item[i]] >= h.key[h.item[parent]] {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+func (h *indexHeap) down(i int) {
+	n := len(h.item)
+	for {
+		left, right := 2*i+1, 2*i+2
+		smallest := i
+		if left < n && h.key[h.item[left]] < h.key[h.item[smallest]] {
+			smallest = left
+		}
+		if right < n && h.key[h.item[right]] < h.key[h.item[smallest]] {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// Update 插入顶点v，已在堆中则按新的key上浮
+func (h *indexHeap) Update(v int) {
+	if h.pos[v] == -1 {
+		h.item = append(h.item, v)
+		h.pos[v] = len(h.item) - 1
+	}
+	h.up(h.pos[v])
+}
+
+func (h *indexHeap) Pop() int {
+	v := h.item[0]
+	h.swap(0, len(h.item)-1)
+	h.item = h.item[:len(h.item)-1]
+	h.pos[v] = -1
+	h.down(0)
+	return v
+}
+
+// Dijkstra 边权非负的单源最短路，O((V+E)logV)
+func Dijkstra(g *Graph, src int) *ShortestPaths {
+	sp := newShortestPaths(g.n, src)
+	h := newIndexHeap(g.n, sp.Dist)
+	h.Update(src)
+	for h.Len() > 0 {
+		u := h.Pop()
+		for _, e := range g.adj[u] {
+			if e.Weight < 0 {
+				panic("dijkstra: negative edge weight")
+			}
+			if d := sp.Dist[u] + e.Weight; d < sp.Dist[e.To] {
+				sp.Dist[e.To] = d
+				sp.Prev[e.To] = u
+				h.Update(e.To)
+			}
+		}
+	}
+	return sp
+}
+
+// BellmanFord 允许负权边的单源最短路，O(VE)
+// 若从源点可达负环，返回的第二个值为环上的顶点，首尾相同
+func BellmanFord(g *Graph, src int) (*ShortestPaths, []int) {
+	sp := newShortestPaths(g.n, src)
+	relax := func() int {
+		last := -1
+		for u := 0; u < g.n; u++ {
+			if sp.Dist[u] == Inf {
+				continue
+			}
+			for _, e := range g.adj[u] {
+				if d := sp.Dist[u] + e.Weight; d < sp.Dist[e.To] {
+					sp.Dist[e.To] = d
+					sp.Prev[e.To] = u
+					last = e.To
+				}
+			}
+		}
+		return last
+	}
+	// 最多n-1轮松弛，第n轮仍能松弛说明存在负环
+	last := -1
+	for i := 0; i < g.n; i++ {
+		last = relax()
+		if last == -1 {
+			return sp, nil
+		}
+	}
+	// 沿前驱走n步一定落在环上
+	v := last
+	for i := 0; i < g.n; i++ {
+		v = sp.Prev[v]
+	}
+	cycle := []int{v}
+	for x := sp.Prev[v]; x != v; x = sp.Prev[x] {
+		cycle = append(cycle, x)
+	}
+	cycle = append(cycle, v)
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return sp, cycle
+}
+
+// ZeroOneBFS 边权只有0和1时的最短路，双端队列，权为0的边放队首
+func ZeroOneBFS(g *Graph, src int) *ShortestPaths {
+	sp := newShortestPaths(g.n, src)
+	// 用两个栈拼成的双端队列: front逆序存放队首部分
+	front, back := []int{src}, []int{}
+	for len(front) > 0 || len(back) > 0 {
+		var u int
+		if len(front) > 0 {
+			u = front[len(front)-1]
+			front = front[:len(front)-1]
+		} else {
+			u = back[0]
+			back = back[1:]
+		}
+		for _, e := range g.adj[u] {
+			if e.Weight != 0 && e.Weight != 1 {
+				panic("0-1 bfs: edge weight must be 0 or 1")
+			}
+			if d := sp.Dist[u] + e.Weight; d < sp.Dist[e.To] {
+				sp.Dist[e.To] = d
+				sp.Prev[e.To] = u
+				if e.Weight == 0 {
+					front = append(front, e.To)
+				} else {
+					back = append(back, e.To)
+				}
+			}
+		}
+	}
+	return sp
+}
+
+// AllPairs 全源最短路的结果
+type AllPairs struct {
+	Dist [][]int
+	next [][]int // next[u][v]为u到v最短路上u之后的顶点
+}
+
+// FloydWarshall 全源最短路，O(V^3)
+func FloydWarshall(g *Graph) *AllPairs {
+	n := g.n
+	ap := &AllPairs{Dist: make([][]int, n), next: make([][]int, n)}
+	for i := 0; i < n; i++ {
+		ap.Dist[i] = make([]int, n)
+		ap.next[i] = make([]int, n)
+		for j := 0; j < n; j++ {
+			ap.Dist[i][j] = Inf
+			ap.next[i][j] = -1
+		}
+		ap.Dist[i][i] = 0
+		ap.next[i][i] = i
+	}
+	for u := 0; u < n; u++ {
+		for _, e := range g.adj[u] {
+			if e.Weight < ap.Dist[u][e.To] {
+				ap.Dist[u][e.To] = e.Weight
+				ap.next[u][e.To] = e.To
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if ap.Dist[i][k] == Inf {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if ap.Dist[k][j] == Inf {
+					continue
+				}
+				if d := ap.Dist[i][k] + ap.Dist[k][j]; d < ap.Dist[i][j] {
+					ap.Dist[i][j] = d
+					ap.next[i][j] = ap.next[i][k]
+				}
+			}
+		}
+	}
+	return ap
+}
+
+// HasNegativeCycle 对角线出现负数说明存在负环
+func (ap *AllPairs) HasNegativeCycle() bool {
+	for i := range ap.Dist {
+		if ap.Dist[i][i] < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Path 返回u到v的最短路径，不可达返回nil
+func (ap *AllPairs) Path(u, v int) []int {
+	if ap.next[u][v] == -1 {
+		return nil
+	}
+	path := []int{u}
+	for u != v {
+		u = ap.next[u][v]
+		path = append(path, u)
+		if len(path) > len(ap.Dist) {
+			// 路径经过负环，最短路不存在
+			return nil
+		}
+	}
+	return path
+}
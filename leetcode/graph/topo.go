@@ -0,0 +1,194 @@
+package graph
+
+import "fmt"
+
+// TopoSortKahn 入度为0的顶点依次出队，得到的序列即拓扑序
+// 存在环时返回 false
+func TopoSortKahn(g *Graph) ([]int, bool) {
+	in := g.InDegrees()
+	queue := []int{}
+	for i := 0; i < g.n; i++ {
+		if in[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	order := make([]int, 0, g.n)
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		order = append(order, u)
+		for _, e := range g.adj[u] {
+			in[e.To]--
+			if in[e.To] == 0 {
+				queue = append(queue, e.To)
+			}
+		}
+	}
+	if len(order) != g.n {
+		return nil, false
+	}
+	return order, true
+}
+
+// TopoSortDFS 三色dfs，未访问0，访问中1，已完成2
+// 后序遍历的逆序即拓扑序，遇到访问中的顶点说明有环
+func TopoSortDFS(g *Graph) ([]int, bool) {
+	color := make([]int, g.n)
+	post := make([]int, 0, g.n)
+	var dfs func(u int) bool
+	dfs = func(u int) bool {
+		color[u] = 1
+		for _, e := range g.adj[u] {
+			if color[e.To] == 1 {
+				return false
+			}
+			if color[e.To] == 0 && !dfs(e.To) {
+				return false
+			}
+		}
+		color[u] = 2
+		post = append(post, u)
+		return true
+	}
+	for i := 0; i < g.n; i++ {
+		if color[i] == 0 && !dfs(i) {
+			return nil, false
+		}
+	}
+	for i, j := 0, len(post)-1; i < j; i, j = i+1, j-1 {
+		post[i], post[j] = post[j], post[i]
+	}
+	return post, true
+}
+
+// FindCycle 返回图中的一个环，首尾顶点相同，如 [1 2 3 1]
+// 无环时返回 nil
+func FindCycle(g *Graph) []int {
+	color := make([]int, g.n)
+	parent := make([]int, g.n)
+	var cycle []int
+	var dfs func(u int) bool
+	dfs = func(u int) bool {
+		color[u] = 1
+		for _, e := range g.adj[u] {
+			v := e.To
+			if color[v] == 1 {
+				// 沿parent从u回溯到v，得到 v -> ... -> u -> v
+				cycle = []int{v}
+				for x := u; x != v; x = parent[x] {
+					cycle = append(cycle, x)
+				}
+				cycle = append(cycle, v)
+				for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return true
+			}
+			if color[v] == 0 {
+				parent[v] = u
+				if dfs(v) {
+					return true
+				}
+			}
+		}
+		color[u] = 2
+		return false
+	}
+	for i := 0; i < g.n; i++ {
+		if color[i] == 0 && dfs(i) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// Levels 按层剥离入度为0的顶点，同一层的顶点可以在同一学期并行修读
+func Levels(g *Graph) ([][]int, bool) {
+	in := g.InDegrees()
+	cur := []int{}
+	for i := 0; i < g.n; i++ {
+		if in[i] == 0 {
+			cur = append(cur, i)
+		}
+	}
+	var levels [][]int
+	seen := 0
+	for len(cur) > 0 {
+		levels = append(levels, cur)
+		seen += len(cur)
+		next := []int{}
+		for _, u := range cur {
+			for _, e := range g.adj[u] {
+				in[e.To]--
+				if in[e.To] == 0 {
+					next = append(next, e.To)
+				}
+			}
+		}
+		cur = next
+	}
+	if seen != g.n {
+		return nil, false
+	}
+	return levels, true
+}
+
+// CriticalPath 带权DAG上的最长路径(关键路径)，返回路径总权重和经过的顶点
+// 存在环时返回 false
+func CriticalPath(g *Graph) (int, []int, bool) {
+	order, ok := TopoSortKahn(g)
+	if !ok {
+		return 0, nil, false
+	}
+	if g.n == 0 {
+		return 0, nil, true
+	}
+	// dist[v]表示以v结尾的最长路径长度
+	dist := make([]int, g.n)
+	prev := make([]int, g.n)
+	for i := range prev {
+		prev[i] = -1
+	}
+	for _, u := range order {
+		for _, e := range g.adj[u] {
+			if dist[u]+e.Weight > dist[e.To] || (prev[e.To] == -1 && dist[u]+e.Weight == dist[e.To]) {
+				dist[e.To] = dist[u] + e.Weight
+				prev[e.To] = u
+			}
+		}
+	}
+	end := 0
+	for v := range dist {
+		if dist[v] > dist[end] {
+			end = v
+		}
+	}
+	path := []int{}
+	for v := end; v != -1; v = prev[v] {
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return dist[end], path, true
+}
+
+func TestTopoSort() {
+	// 课程表: [a, b] 表示先修b
+	g := FromPrerequisites(6, [][]int{{1, 0}, {2, 0}, {3, 1}, {3, 2}, {4, 3}, {5, 3}})
+	fmt.Println(TopoSortKahn(g))
+	fmt.Println(TopoSortDFS(g))
+	fmt.Println(Levels(g))
+
+	cyclic := FromPrerequisites(4, [][]int{{1, 0}, {2, 1}, {3, 2}, {1, 3}})
+	fmt.Println(TopoSortKahn(cyclic))
+	fmt.Println(FindCycle(cyclic))
+
+	w := New(5)
+	w.AddWeightedEdge(0, 1, 3)
+	w.AddWeightedEdge(0, 2, 2)
+	w.AddWeightedEdge(1, 3, 4)
+	w.AddWeightedEdge(2, 3, 6)
+	w.AddWeightedEdge(3, 4, 1)
+	fmt.Println(CriticalPath(w)) // 9 [0 2 3 4]
+}
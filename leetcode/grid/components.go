@@ -0,0 +1,136 @@
+package grid
+
+import (
+	"fmt"
+	"time"
+)
+
+// FloodFill 从start出发，用显式栈遍历所有满足match且连通的格子，返回面积
+// seen记录已访问的格子(按Index下标)，为nil时内部分配；大网格上也不会因递归过深而栈溢出
+func (g *Grid[T]) FloodFill(start Point, match func(T) bool, dirs []Point, seen []bool, visit func(Point)) int {
+	if !g.InBounds(start) || !match(g.At(start)) {
+		return 0
+	}
+	if seen == nil {
+		seen = make([]bool, len(g.cells))
+	}
+	s := g.Index(start)
+	if seen[s] {
+		return 0
+	}
+	seen[s] = true
+	stack := []int{s}
+	area := 0
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		area++
+		p := Point{i / g.cols, i % g.cols}
+		if visit != nil {
+			visit(p)
+		}
+		for _, d := range dirs {
+			q := p.Add(d)
+			if !g.InBounds(q) {
+				continue
+			}
+			j := q.R*g.cols + q.C
+			if !seen[j] && match(g.cells[j]) {
+				seen[j] = true
+				stack = append(stack, j)
+			}
+		}
+	}
+	return area
+}
+
+// Label 连通分量标记，背景为0，分量按行优先扫描时首次出现的顺序编号为1..count
+func Label[T any](g *Grid[T], match func(T) bool, dirs []Point) (*Grid[int32], int) {
+	labels := New[int32](g.rows, g.cols)
+	count := 0
+	stack := []int{}
+	for s := range g.cells {
+		if labels.cells[s] != 0 || !match(g.cells[s]) {
+			continue
+		}
+		count++
+		id := int32(count)
+		labels.cells[s] = id
+		stack = append(stack[:0], s)
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			p := Point{i / g.cols, i % g.cols}
+			for _, d := range dirs {
+				q := p.Add(d)
+				if !g.InBounds(q) {
+					continue
+				}
+				j := q.R*g.cols + q.C
+				if labels.cells[j] == 0 && match(g.cells[j]) {
+					labels.cells[j] = id
+					stack = append(stack, j)
+				}
+			}
+		}
+	}
+	return labels, count
+}
+
+// Island 一个岛屿的统计信息
+type Island struct {
+	Label     int
+	Area      int
+	Perimeter int   // 与水或边界相邻的边数，按四连通计算
+	Min, Max  Point // 外接矩形
+}
+
+// Islands 统计每个岛屿的面积、周长和外接矩形，按Label顺序返回
+func Islands[T any](g *Grid[T], match func(T) bool, dirs []Point) []Island {
+	labels, count := Label(g, match, dirs)
+	res := make([]Island, count)
+	for i := range res {
+		res[i] = Island{Label: i + 1, Min: Point{g.rows, g.cols}, Max: Point{-1, -1}}
+	}
+	for i, id := range labels.cells {
+		if id == 0 {
+			continue
+		}
+		is := &res[id-1]
+		p := Point{i / g.cols, i % g.cols}
+		is.Area++
+		is.Min = Point{min(is.Min.R, p.R), min(is.Min.C, p.C)}
+		is.Max = Point{max(is.Max.R, p.R), max(is.Max.C, p.C)}
+		for _, d := range Dir4 {
+			q := p.Add(d)
+			if !g.InBounds(q) || labels.At(q) == 0 {
+				is.Perimeter++
+			}
+		}
+	}
+	return res
+}
+
+func TestGrid() {
+	data := [][]byte{
+		[]byte("11000"),
+		[]byte("11011"),
+		[]byte("00100"),
+		[]byte("00011"),
+	}
+	g := From(data)
+	isLand := func(b byte) bool { return b == '1' }
+	_, n4 := Label(g, isLand, Dir4)
+	_, n8 := Label(g, isLand, Dir8)
+	fmt.Println(n4, n8)
+	fmt.Println(Islands(g, isLand, Dir4))
+
+	// 全是陆地的大网格，递归dfs的深度会达到格子数
+	big := New[byte](3000, 3000)
+	for i := range big.cells {
+		big.cells[i] = '1'
+	}
+	start := time.Now()
+	_, n := Label(big, isLand, Dir4)
+	fmt.Println(n, big.FloodFill(Point{0, 0}, isLand, Dir4, nil, nil), time.Since(start))
+}
@@ -0,0 +1,106 @@
+package grid
+
+import "iter"
+
+// Point 网格中的坐标
+type Point struct {
+	R, C int
+}
+
+func (p Point) Add(d Point) Point {
+	return Point{p.R + d.R, p.C + d.C}
+}
+
+// Dir4 四连通方向
+var Dir4 = []Point{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+
+// Dir8 八连通方向
+var Dir8 = []Point{{0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}, {-1, 0}, {-1, 1}}
+
+// Grid 按行优先存储在一维切片中的二维网格
+type Grid[T any] struct {
+	rows, cols int
+	cells      []T
+}
+
+func New[T any](rows, cols int) *Grid[T] {
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// From 从二维切片复制出网格，要求每行长度相同
+func From[T any](data [][]T) *Grid[T] {
+	if len(data) == 0 {
+		return New[T](0, 0)
+	}
+	g := New[T](len(data), len(data[0]))
+	for i, row := range data {
+		copy(g.cells[i*g.cols:(i+1)*g.cols], row)
+	}
+	return g
+}
+
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.R >= 0 && p.R < g.rows && p.C >= 0 && p.C < g.cols
+}
+
+// Index 坐标转一维下标
+func (g *Grid[T]) Index(p Point) int {
+	return p.R*g.cols + p.C
+}
+
+// Point 一维下标转坐标
+func (g *Grid[T]) Point(i int) Point {
+	return Point{i / g.cols, i % g.cols}
+}
+
+func (g *Grid[T]) At(p Point) T {
+	return g.cells[p.R*g.cols+p.C]
+}
+
+func (g *Grid[T]) Set(p Point, v T) {
+	g.cells[p.R*g.cols+p.C] = v
+}
+
+// Cells 底层按行优先存储的切片
+func (g *Grid[T]) Cells() []T {
+	return g.cells
+}
+
+// All 按行优先遍历所有格子
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{i / g.cols, i % g.cols}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors 按给定方向遍历p在边界内的相邻格子
+func (g *Grid[T]) Neighbors(p Point, dirs []Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, d := range dirs {
+			q := p.Add(d)
+			if g.InBounds(q) && !yield(q) {
+				return
+			}
+		}
+	}
+}
+
+func (g *Grid[T]) Neighbors4(p Point) iter.Seq[Point] {
+	return g.Neighbors(p, Dir4)
+}
+
+func (g *Grid[T]) Neighbors8(p Point) iter.Seq[Point] {
+	return g.Neighbors(p, Dir8)
+}
@@ -0,0 +1,142 @@
+package grid
+
+import "leetcode/structure"
+
+// IslandCounter 在线维护四连通岛屿数量
+//
+// 加陆地: 新建并查集节点并与相邻陆地合并，均摊近似O(1)。
+// 删陆地: 并查集不支持拆分，采用局部重建:
+//   - 相邻陆地不超过1个时，删除不会使岛屿断开，无需重建；
+//   - 否则从各相邻陆地出发做bfs，每个连通块共用一个新的并查集节点，
+//     bfs的次数即原岛屿分裂成的岛屿数，代价为原岛屿的面积。
+//
+// 被替换的旧节点成为垃圾，数量超过存活陆地的两倍时整体压缩一次。
+type IslandCounter struct {
+	rows, cols int
+	node       []int // 每个格子对应的并查集节点，水为-1
+	ds         *structure.UnionSet
+	land       int
+	count      int
+}
+
+func NewIslandCounter(rows, cols int) *IslandCounter {
+	ic := &IslandCounter{rows: rows, cols: cols, node: make([]int, rows*cols), ds: structure.NewUnionSetN(0)}
+	for i := range ic.node {
+		ic.node[i] = -1
+	}
+	return ic
+}
+
+func (ic *IslandCounter) inBounds(r, c int) bool {
+	return r >= 0 && r < ic.rows && c >= 0 && c < ic.cols
+}
+
+func (ic *IslandCounter) IsLand(r, c int) bool {
+	return ic.inBounds(r, c) && ic.node[r*ic.cols+c] != -1
+}
+
+// Count 当前岛屿数量
+func (ic *IslandCounter) Count() int {
+	return ic.count
+}
+
+// AddLand 将(r, c)变为陆地，返回之后的岛屿数量
+func (ic *IslandCounter) AddLand(r, c int) int {
+	if !ic.inBounds(r, c) || ic.IsLand(r, c) {
+		return ic.count
+	}
+	x := ic.ds.Len()
+	ic.ds.Add(x)
+	ic.node[r*ic.cols+c] = x
+	ic.land++
+	ic.count++
+	for _, d := range Dir4 {
+		if ic.IsLand(r+d.R, c+d.C) && ic.ds.Union(x, ic.node[(r+d.R)*ic.cols+c+d.C]) {
+			ic.count--
+		}
+	}
+	return ic.count
+}
+
+// RemoveLand 将(r, c)变为水，返回之后的岛屿数量
+func (ic *IslandCounter) RemoveLand(r, c int) int {
+	if !ic.IsLand(r, c) {
+		return ic.count
+	}
+	ic.node[r*ic.cols+c] = -1
+	ic.land--
+	var neighbors []int
+	for _, d := range Dir4 {
+		if ic.IsLand(r+d.R, c+d.C) {
+			neighbors = append(neighbors, (r+d.R)*ic.cols+c+d.C)
+		}
+	}
+	if len(neighbors) == 0 {
+		ic.count--
+		return ic.count
+	}
+	if len(neighbors) == 1 {
+		return ic.count
+	}
+	if ic.ds.Len() > 2*ic.land+1024 {
+		ic.rebuild()
+		return ic.count
+	}
+	// 编号不小于fresh的节点是本次重建分配的，据此判断格子是否已访问
+	fresh := ic.ds.Len()
+	parts := 0
+	for _, s := range neighbors {
+		if ic.node[s] >= fresh {
+			continue
+		}
+		parts++
+		ic.relabel(s, fresh)
+	}
+	ic.count += parts - 1
+	return ic.count
+}
+
+// relabel 从下标s出发bfs，整个连通块共用一个新节点，节点编号不小于fresh的格子视为已访问
+func (ic *IslandCounter) relabel(s, fresh int) {
+	root := ic.ds.Len()
+	ic.ds.Add(root)
+	ic.node[s] = root
+	queue := []int{s}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		r, c := i/ic.cols, i%ic.cols
+		for _, d := range Dir4 {
+			if !ic.IsLand(r+d.R, c+d.C) {
+				continue
+			}
+			j := (r+d.R)*ic.cols + c + d.C
+			if ic.node[j] >= fresh {
+				continue
+			}
+			ic.node[j] = root
+			queue = append(queue, j)
+		}
+	}
+}
+
+// rebuild 丢弃所有旧节点，重新标记全部岛屿
+func (ic *IslandCounter) rebuild() {
+	ic.ds = structure.NewUnionSetN(0)
+	old := ic.node
+	ic.node = make([]int, len(old))
+	for i := range ic.node {
+		ic.node[i] = -1
+		if old[i] != -1 {
+			// 先标记为一个不会与新节点冲突的值，表示是陆地但尚未分配
+			ic.node[i] = -2
+		}
+	}
+	ic.count = 0
+	for i := range ic.node {
+		if ic.node[i] == -2 {
+			ic.count++
+			ic.relabel(i, 0)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package grid
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"leetcode/structure"
+)
+
+type tile struct {
+	r0, r1, c0, c1 int   // 行[r0, r1) 列[c0, c1)
+	seeds          []int // 第k个局部分量的起点，也是该分量中行优先最小的下标
+	offset         int   // 局部编号加上offset得到全局编号
+}
+
+// labelTile 只在tile内部做连通分量标记，局部编号从1开始
+func labelTile[T any](g *Grid[T], match func(T) bool, dirs []Point, labels []int32, t *tile) {
+	stack := []int{}
+	for r := t.r0; r < t.r1; r++ {
+		for c := t.c0; c < t.c1; c++ {
+			s := r*g.cols + c
+			if labels[s] != 0 || !match(g.cells[s]) {
+				continue
+			}
+			t.seeds = append(t.seeds, s)
+			id := int32(len(t.seeds))
+			labels[s] = id
+			stack = append(stack[:0], s)
+			for len(stack) > 0 {
+				i := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				pr, pc := i/g.cols, i%g.cols
+				for _, d := range dirs {
+					qr, qc := pr+d.R, pc+d.C
+					if qr < t.r0 || qr >= t.r1 || qc < t.c0 || qc >= t.c1 {
+						continue
+					}
+					j := qr*g.cols + qc
+					if labels[j] == 0 && match(g.cells[j]) {
+						labels[j] = id
+						stack = append(stack, j)
+					}
+				}
+			}
+		}
+	}
+}
+
+// parallelTiles 用最多workers个goroutine对每个tile执行f
+func parallelTiles(tiles []*tile, workers int, f func(t *tile)) {
+	ch := make(chan *tile)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range ch {
+				f(t)
+			}
+		}()
+	}
+	for _, t := range tiles {
+		ch <- t
+	}
+	close(ch)
+	wg.Wait()
+}
+
+// LabelParallel 分块并行的连通分量标记，结果与Label完全相同
+//  1. 网格切分为 tileSize x tileSize 的块，各块并发地做局部标记；
+//  2. 局部编号加上偏移量成为全局编号，用并查集合并跨越块边界相邻的分量；
+//  3. 按每个分量行优先最早出现的位置重新编号，并发写回。
+func LabelParallel[T any](g *Grid[T], match func(T) bool, dirs []Point, tileSize, workers int) (*Grid[int32], int) {
+	if tileSize <= 0 {
+		tileSize = 512
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	labels := New[int32](g.rows, g.cols)
+	var tiles []*tile
+	for r := 0; r < g.rows; r += tileSize {
+		for c := 0; c < g.cols; c += tileSize {
+			tiles = append(tiles, &tile{r0: r, r1: min(r+tileSize, g.rows), c0: c, c1: min(c+tileSize, g.cols)})
+		}
+	}
+	parallelTiles(tiles, workers, func(t *tile) {
+		labelTile(g, match, dirs, labels.cells, t)
+	})
+
+	// 全局编号从1开始，0保留给背景
+	total := 1
+	for _, t := range tiles {
+		t.offset = total - 1
+		total += len(t.seeds)
+	}
+	first := make([]int, total)
+	for _, t := range tiles {
+		for k, s := range t.seeds {
+			first[t.offset+k+1] = s
+		}
+	}
+	parallelTiles(tiles, workers, func(t *tile) {
+		for r := t.r0; r < t.r1; r++ {
+			for i := r*g.cols + t.c0; i < r*g.cols+t.c1; i++ {
+				if labels.cells[i] != 0 {
+					labels.cells[i] += int32(t.offset)
+				}
+			}
+		}
+	})
+
+	// 合并跨块边界的分量，只需检查块的边框格子
+	ds := structure.NewUnionSetN(total)
+	for _, t := range tiles {
+		for r := t.r0; r < t.r1; r++ {
+			for c := t.c0; c < t.c1; c++ {
+				if r != t.r0 && r != t.r1-1 && c != t.c0 && c != t.c1-1 {
+					c = t.c1 - 2 // 跳过内部
+					continue
+				}
+				x := labels.cells[r*g.cols+c]
+				if x == 0 {
+					continue
+				}
+				for _, d := range dirs {
+					q := Point{r + d.R, c + d.C}
+					if !g.InBounds(q) || (q.R >= t.r0 && q.R < t.r1 && q.C >= t.c0 && q.C < t.c1) {
+						continue
+					}
+					if y := labels.At(q); y != 0 {
+						ds.Union(int(x), int(y))
+					}
+				}
+			}
+		}
+	}
+
+	// 每个集合以最早出现的位置排序，得到与Label一致的编号
+	root := make([]int, total)
+	rootFirst := make([]int, total)
+	for x := range rootFirst {
+		rootFirst[x] = math.MaxInt
+	}
+	var roots []int
+	for x := 1; x < total; x++ {
+		root[x] = ds.Find(x)
+		if rootFirst[root[x]] == math.MaxInt {
+			roots = append(roots, root[x])
+		}
+		rootFirst[root[x]] = min(rootFirst[root[x]], first[x])
+	}
+	slices.SortFunc(roots, func(a, b int) int {
+		return rootFirst[a] - rootFirst[b]
+	})
+	rank := make([]int32, total)
+	for i, x := range roots {
+		rank[x] = int32(i + 1)
+	}
+	canon := make([]int32, total)
+	for x := 1; x < total; x++ {
+		canon[x] = rank[root[x]]
+	}
+	parallelTiles(tiles, workers, func(t *tile) {
+		for r := t.r0; r < t.r1; r++ {
+			for i := r*g.cols + t.c0; i < r*g.cols+t.c1; i++ {
+				labels.cells[i] = canon[labels.cells[i]]
+			}
+		}
+	})
+	return labels, len(roots)
+}
+
+// 比较分块并行与顺序标记的结果和耗时
+func TestLabelParallel() {
+	r := rand.New(rand.NewSource(5))
+	isLand := func(b byte) bool { return b == '1' }
+	// 小网格、各种块大小，与顺序结果逐格比较
+	bad := 0
+	for round := 0; round < 200; round++ {
+		g := New[byte](1+r.Intn(40), 1+r.Intn(40))
+		for i := range g.cells {
+			g.cells[i] = '0'
+			if r.Intn(100) < 55 {
+				g.cells[i] = '1'
+			}
+		}
+		for _, dirs := range [][]Point{Dir4, Dir8} {
+			want, wn := Label(g, isLand, dirs)
+			got, gn := LabelParallel(g, isLand, dirs, 1+r.Intn(10), 1+r.Intn(4))
+			if wn != gn {
+				bad++
+				continue
+			}
+			for i := range want.cells {
+				if want.cells[i] != got.cells[i] {
+					bad++
+					break
+				}
+			}
+		}
+	}
+	fmt.Println("mismatches:", bad)
+
+	n := 10000
+	g := New[byte](n, n)
+	for i := range g.cells {
+		g.cells[i] = '0'
+		if r.Intn(100) < 55 {
+			g.cells[i] = '1'
+		}
+	}
+	start := time.Now()
+	want, wn := Label(g, isLand, Dir4)
+	seq := time.Since(start)
+	for _, procs := range []int{1, 2, 4, 8} {
+		start = time.Now()
+		got, gn := LabelParallel(g, isLand, Dir4, 512, procs)
+		par := time.Since(start)
+		same := wn == gn
+		for i := 0; same && i < len(want.cells); i++ {
+			same = want.cells[i] == got.cells[i]
+		}
+		fmt.Printf("cpus=%d %dx%d workers=%d sequential=%v parallel=%v speedup=%.2f identical=%v\n",
+			runtime.NumCPU(), n, n, procs, seq, par, float64(seq)/float64(par), same)
+	}
+}
@@ -0,0 +1,191 @@
+package interval
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net/netip"
+	"slices"
+)
+
+const universe = 64
+
+// bitmap 暴力表示: covered[x]表示整数点x是否被覆盖(半开区间[x, x+1))
+type bitmap [universe]bool
+
+func (b *bitmap) set(lo, hi int, v bool) {
+	for x := max(lo, 0); x < min(hi, universe); x++ {
+		b[x] = v
+	}
+}
+
+// runs 连续被覆盖的段，半开区间
+func (b *bitmap) runs() [][]int {
+	var res [][]int
+	for x := 0; x < universe; x++ {
+		if b[x] && (x == 0 || !b[x-1]) {
+			res = append(res, []int{x, x + 1})
+		} else if b[x] {
+			res[len(res)-1][1] = x + 1
+		}
+	}
+	return res
+}
+
+func toHalfOpen(intervals [][]int, mode Mode) [][]int {
+	res := make([][]int, len(intervals))
+	for i, in := range intervals {
+		res[i] = []int{in[0], in[1]}
+		if mode == Closed {
+			res[i][1]++
+		}
+	}
+	return res
+}
+
+func equalIntervals(a, b [][]int) bool {
+	return slices.EqualFunc(a, b, slices.Equal[[]int])
+}
+
+func coverage(s *IntervalSet, want int) bool {
+	c, ok := s.Coverage()
+	return ok && c == want
+}
+
+// 随机增删后与位图对比
+func TestIntervalSet() {
+	r := rand.New(rand.NewSource(42))
+	bad := map[string]int{}
+	for round := 0; round < 300; round++ {
+		mode := Mode(round % 2)
+		s, t := New(mode), New(mode)
+		var bs, bt bitmap
+		for op := 0; op < 40; op++ {
+			lo := r.Intn(universe)
+			hi := min(universe-1, lo+r.Intn(12)-1)
+			set, b := s, &bs
+			if r.Intn(3) == 0 {
+				set, b = t, &bt
+			}
+			end := hi
+			if mode == Closed {
+				end = hi + 1
+			}
+			if r.Intn(3) == 0 {
+				set.Remove(lo, hi)
+				b.set(lo, end, false)
+			} else {
+				set.Add(lo, hi)
+				b.set(lo, end, true)
+			}
+
+			if !equalIntervals(toHalfOpen(s.ToSlices(), mode), bs.runs()) {
+				bad["add/remove"]++
+			}
+			x := r.Intn(universe)
+			if s.Contains(x) != bs[x] {
+				bad["Contains"]++
+			}
+			qlo := r.Intn(universe)
+			qhi := min(universe-1, qlo+r.Intn(10))
+			qend := qhi
+			if mode == Closed {
+				qend++
+			}
+			all, some := true, false
+			var covered, gaps bitmap
+			for x := qlo; x < qend; x++ {
+				all = all && bs[x]
+				some = some || bs[x]
+				covered[x], gaps[x] = bs[x], !bs[x]
+			}
+			if s.ContainsRange(qlo, qhi) != all {
+				bad["ContainsRange"]++
+			}
+			if s.Overlaps(qlo, qhi) != some {
+				bad["Overlaps"]++
+			}
+			if !equalIntervals(toHalfOpen(s.Covered(qlo, qhi), mode), covered.runs()) {
+				bad["Covered"]++
+			}
+			if !equalIntervals(toHalfOpen(s.Gaps(qlo, qhi), mode), gaps.runs()) {
+				bad["Gaps"]++
+			}
+		}
+		var union, inter bitmap
+		n := 0
+		for x := range universe {
+			union[x], inter[x] = bs[x] || bt[x], bs[x] && bt[x]
+			if bs[x] {
+				n++
+			}
+		}
+		if !equalIntervals(toHalfOpen(s.Union(t).ToSlices(), mode), union.runs()) {
+			bad["Union"]++
+		}
+		if !equalIntervals(toHalfOpen(s.Intersect(t).ToSlices(), mode), inter.runs()) {
+			bad["Intersect"]++
+		}
+		if c, ok := s.Coverage(); !ok || c != n {
+			bad["Coverage"]++
+		}
+	}
+	// 端点取到int的极值，Closed模式下[lo, math.MaxInt]不能因为hi+1溢出而变成空区间
+	const big, small = math.MaxInt, math.MinInt
+	e := New(Closed)
+	e.Add(big-2, big)
+	e.Add(big-5, big-3) // 与[big-2, big]相接
+	e.Add(small, small+1)
+	if !equalIntervals(e.ToSlices(), [][]int{{small, small + 1}, {big - 5, big}}) ||
+		!e.Contains(big) || !e.Contains(small) || !e.ContainsRange(big-5, big) || !e.Overlaps(big, big) ||
+		!equalIntervals(e.Gaps(big-7, big), [][]int{{big - 7, big - 6}}) ||
+		!equalIntervals(e.Covered(big-1, big), [][]int{{big - 1, big}}) || !coverage(e, 8) {
+		bad["edges"]++
+	}
+	// 覆盖整个int范围时长度为2^64，超出int
+	all := New(Closed)
+	all.Add(small, big)
+	half := New(Closed)
+	half.Add(1, big)
+	if _, ok := all.Coverage(); ok || !coverage(half, big) {
+		bad["edges"]++
+	}
+	half.Add(-1, -1)
+	if _, ok := half.Coverage(); ok {
+		bad["edges"]++
+	}
+	e.Remove(big, big)
+	if e.Contains(big) || !e.Contains(big-1) || !equalIntervals(e.Gaps(big-1, big), [][]int{{big, big}}) {
+		bad["edges"]++
+	}
+	h := New(HalfOpen)
+	h.Add(big-1, big)
+	h.Add(small, small+1)
+	h.Add(5, small) // 空区间
+	if h.Len() != 2 || !h.Contains(big-1) || h.Contains(big) || !h.Contains(small) ||
+		!equalIntervals(h.Gaps(big-3, big), [][]int{{big - 3, big - 1}}) {
+		bad["edges"]++
+	}
+	fmt.Println("mismatches:", bad)
+
+	// 与merge的格式互转
+	s := FromSlices([][]int{{1, 3}, {2, 6}, {8, 10}, {15, 18}}, Closed)
+	fmt.Println(s, s.ToSlices(), s.Gaps(0, 20))
+	fmt.Println(s.Coverage())
+
+	// IP白名单: IPv4地址映射为整数，闭区间
+	ip := func(s string) int {
+		b := netip.MustParseAddr(s).As4()
+		return int(b[0])<<24 | int(b[1])<<16 | int(b[2])<<8 | int(b[3])
+	}
+	allow := New(Closed)
+	allow.Add(ip("10.0.0.0"), ip("10.0.0.255"))
+	allow.Add(ip("10.0.1.0"), ip("10.0.1.255")) // 与上一段相接，合并成一段
+	allow.Add(ip("192.168.1.10"), ip("192.168.1.20"))
+	allow.Remove(ip("192.168.1.15"), ip("192.168.1.15"))
+	for _, addr := range []string{"10.0.1.7", "10.0.2.1", "192.168.1.14", "192.168.1.15", "192.168.1.16"} {
+		fmt.Printf("%s %v\n", addr, allow.Contains(ip(addr)))
+	}
+	addresses, _ := allow.Coverage()
+	fmt.Println("ranges:", allow.Len(), "addresses:", addresses)
+}
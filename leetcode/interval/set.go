@@ -0,0 +1,282 @@
+package interval
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"strings"
+)
+
+// Mode 区间端点的含义
+type Mode int
+
+const (
+	HalfOpen Mode = iota // [lo, hi)，hi <= lo为空区间，例如日程 [start, end)
+	Closed               // [lo, hi]，端点为整数，[1,2]和[3,4]会合并成[1,4]，例如IP段
+)
+
+// IntervalSet 互不相交的区间集合，相邻或重叠的区间自动合并
+// 内部统一存成闭区间[lo, hi]，以左端点为key放在跳表中，增删查期望O(logn + 涉及的区间数)
+// 非空的半开区间[lo, hi)转成[lo, hi-1]不会溢出，闭区间原样保存，math.MaxInt等端点也能表示
+type IntervalSet struct {
+	mode Mode
+	list *skipList
+}
+
+func New(mode Mode) *IntervalSet {
+	return &IntervalSet{mode: mode, list: newSkipList()}
+}
+
+// FromSlices 由[][]int构造，输入可以无序、重叠，与merge的输入格式相同
+func FromSlices(intervals [][]int, mode Mode) *IntervalSet {
+	s := New(mode)
+	for _, in := range intervals {
+		s.Add(in[0], in[1])
+	}
+	return s
+}
+
+// ToSlices 按左端点顺序输出，端点含义与Mode一致
+func (s *IntervalSet) ToSlices() [][]int {
+	res := make([][]int, 0, s.Len())
+	for lo, hi := range s.All() {
+		res = append(res, []int{lo, hi})
+	}
+	return res
+}
+
+func (s *IntervalSet) Mode() Mode {
+	return s.mode
+}
+
+// Len 不相交区间的个数
+func (s *IntervalSet) Len() int {
+	return s.list.len
+}
+
+// norm 转成内部的闭区间，空区间返回false
+func (s *IntervalSet) norm(lo, hi int) (int, int, bool) {
+	if s.mode == Closed {
+		return lo, hi, lo <= hi
+	}
+	if hi <= lo {
+		return 0, 0, false
+	}
+	return lo, hi - 1, true
+}
+
+// denorm 转回外部表示，半开区间的右端点由norm减一得到，加回去不会溢出
+func (s *IntervalSet) denorm(lo, hi int) (int, int) {
+	if s.mode == Closed {
+		return lo, hi
+	}
+	return lo, hi + 1
+}
+
+// touches 以end结尾的区间与从start开始的区间重叠或相邻，即end >= start-1
+// 拆成两个条件避免start-1或end+1溢出
+func touches(end, start int) bool {
+	return end >= start || end+1 == start
+}
+
+// All 按顺序遍历所有区间
+func (s *IntervalSet) All() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for n := s.list.first(); n != nil; n = n.next[0] {
+			if !yield(s.denorm(n.key, n.val)) {
+				return
+			}
+		}
+	}
+}
+
+// Add 加入区间，与已有区间重叠或相邻时合并
+func (s *IntervalSet) Add(lo, hi int) {
+	if lo, hi, ok := s.norm(lo, hi); ok {
+		s.add(lo, hi)
+	}
+}
+
+func (s *IntervalSet) add(lo, hi int) {
+	// 左侧与lo重叠或相接的区间
+	if n := s.list.floor(lo); n != nil && touches(n.val, lo) {
+		lo = n.key
+		hi = max(hi, n.val)
+		s.list.delete(n.key)
+	}
+	// 右侧所有与hi重叠或相接的区间
+	for n := s.list.ceiling(lo); n != nil && touches(hi, n.key); n = s.list.ceiling(lo) {
+		hi = max(hi, n.val)
+		s.list.delete(n.key)
+	}
+	s.list.insert(lo, hi)
+}
+
+// Remove 移除区间，被部分覆盖的区间会被截断或拆成两段
+func (s *IntervalSet) Remove(lo, hi int) {
+	lo, hi, ok := s.norm(lo, hi)
+	if !ok {
+		return
+	}
+	if n := s.list.floor(lo); n != nil && n.val >= lo {
+		end := n.val
+		if n.key < lo {
+			n.val = lo - 1
+		} else {
+			s.list.delete(n.key)
+		}
+		if end > hi {
+			s.list.insert(hi+1, end)
+			return
+		}
+	}
+	for n := s.list.ceiling(lo); n != nil && n.key <= hi; n = s.list.ceiling(lo) {
+		s.list.delete(n.key)
+		if n.val > hi {
+			s.list.insert(hi+1, n.val)
+			return
+		}
+	}
+}
+
+// Contains 点x是否被覆盖
+func (s *IntervalSet) Contains(x int) bool {
+	n := s.list.floor(x)
+	return n != nil && x <= n.val
+}
+
+// ContainsRange 区间是否被完全覆盖，空区间返回true
+func (s *IntervalSet) ContainsRange(lo, hi int) bool {
+	lo, hi, ok := s.norm(lo, hi)
+	if !ok {
+		return true
+	}
+	n := s.list.floor(lo)
+	return n != nil && hi <= n.val
+}
+
+// Overlaps 区间是否与集合有交集
+func (s *IntervalSet) Overlaps(lo, hi int) bool {
+	lo, hi, ok := s.norm(lo, hi)
+	if !ok {
+		return false
+	}
+	if n := s.list.floor(lo); n != nil && n.val >= lo {
+		return true
+	}
+	n := s.list.ceiling(lo)
+	return n != nil && n.key <= hi
+}
+
+// Covered 区间中被覆盖的部分，端点含义与Mode一致
+func (s *IntervalSet) Covered(lo, hi int) [][]int {
+	lo, hi, ok := s.norm(lo, hi)
+	if !ok {
+		return nil
+	}
+	var res [][]int
+	s.overlapping(lo, hi, func(a, b int) {
+		a, b = s.denorm(max(a, lo), min(b, hi))
+		res = append(res, []int{a, b})
+	})
+	return res
+}
+
+// Gaps 区间中没有被覆盖的部分，端点含义与Mode一致
+func (s *IntervalSet) Gaps(lo, hi int) [][]int {
+	lo, hi, ok := s.norm(lo, hi)
+	if !ok {
+		return nil
+	}
+	var res [][]int
+	cur, done := lo, false // [cur, hi]尚未处理，done表示已经处理到hi
+	s.overlapping(lo, hi, func(a, b int) {
+		if a > cur {
+			x, y := s.denorm(cur, a-1)
+			res = append(res, []int{x, y})
+		}
+		if b >= hi {
+			done = true
+		} else {
+			cur = b + 1
+		}
+	})
+	if !done {
+		x, y := s.denorm(cur, hi)
+		res = append(res, []int{x, y})
+	}
+	return res
+}
+
+// overlapping 按顺序遍历与闭区间[lo, hi]相交的区间
+func (s *IntervalSet) overlapping(lo, hi int, f func(a, b int)) {
+	n := s.list.floor(lo)
+	if n == nil || n.val < lo {
+		n = s.list.ceiling(lo)
+	}
+	for ; n != nil && n.key <= hi; n = n.next[0] {
+		f(n.key, n.val)
+	}
+}
+
+// Coverage 覆盖的总长度，Closed模式下为覆盖的整数个数
+// 总长度超过math.MaxInt时(例如[math.MinInt, math.MaxInt])返回false
+func (s *IntervalSet) Coverage() (int, bool) {
+	var total uint64
+	for n := s.list.first(); n != nil; n = n.next[0] {
+		// 按无符号数相减不会溢出，得到长度-1
+		d := uint64(n.val) - uint64(n.key)
+		if d >= math.MaxInt || total > math.MaxInt-d-1 {
+			return 0, false
+		}
+		total += d + 1
+	}
+	return int(total), true
+}
+
+// Union 并集，结果使用s的Mode，两个集合的Mode应相同
+func (s *IntervalSet) Union(other *IntervalSet) *IntervalSet {
+	res := New(s.mode)
+	for n := s.list.first(); n != nil; n = n.next[0] {
+		res.list.insert(n.key, n.val)
+	}
+	for n := other.list.first(); n != nil; n = n.next[0] {
+		res.add(n.key, n.val)
+	}
+	return res
+}
+
+// Intersect 交集，双指针同时遍历两个有序区间序列，O(n+m)
+func (s *IntervalSet) Intersect(other *IntervalSet) *IntervalSet {
+	res := New(s.mode)
+	a, b := s.list.first(), other.list.first()
+	for a != nil && b != nil {
+		lo, hi := max(a.key, b.key), min(a.val, b.val)
+		if lo <= hi {
+			res.list.insert(lo, hi)
+		}
+		if a.val < b.val {
+			a = a.next[0]
+		} else {
+			b = b.next[0]
+		}
+	}
+	return res
+}
+
+func (s *IntervalSet) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for lo, hi := range s.All() {
+		if sb.Len() > 1 {
+			sb.WriteByte(' ')
+		}
+		if s.mode == Closed {
+			fmt.Fprintf(&sb, "[%d,%d]", lo, hi)
+		} else {
+			fmt.Fprintf(&sb, "[%d,%d)", lo, hi)
+		}
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+package interval
+
+import "math/rand/v2"
+
+const (
+	maxLevel = 32
+	pLevel   = 4 // 每个节点以1/4的概率升高一层
+)
+
+// node 跳表节点，key为区间左端点，val为右端点
+type node struct {
+	key, val int
+	next     []*node
+}
+
+// skipList 按key有序的跳表，期望O(logn)查找、插入、删除
+type skipList struct {
+	head  *node
+	level int
+	len   int
+	rng   *rand.Rand
+}
+
+func newSkipList() *skipList {
+	return &skipList{
+		head:  &node{next: make([]*node, maxLevel)},
+		level: 1,
+		rng:   rand.New(rand.NewPCG(1, 2)),
+	}
+}
+
+func (l *skipList) randomLevel() int {
+	level := 1
+	for level < maxLevel && l.rng.IntN(pLevel) == 0 {
+		level++
+	}
+	return level
+}
+
+// findPrev 每一层中最后一个key < key的节点
+func (l *skipList) findPrev(key int, update []*node) *node {
+	cur := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for cur.next[i] != nil && cur.next[i].key < key {
+			cur = cur.next[i]
+		}
+		if update != nil {
+			update[i] = cur
+		}
+	}
+	return cur
+}
+
+// floor 最后一个key <= key的节点，不存在返回nil
+func (l *skipList) floor(key int) *node {
+	prev := l.findPrev(key, nil)
+	if n := prev.next[0]; n != nil && n.key == key {
+		return n
+	}
+	if prev == l.head {
+		return nil
+	}
+	return prev
+}
+
+// ceiling 第一个key >= key的节点
+func (l *skipList) ceiling(key int) *node {
+	return l.findPrev(key, nil).next[0]
+}
+
+func (l *skipList) first() *node {
+	return l.head.next[0]
+}
+
+// insert 插入或更新key
+func (l *skipList) insert(key, val int) {
+	var update [maxLevel]*node
+	prev := l.findPrev(key, update[:])
+	if n := prev.next[0]; n != nil && n.key == key {
+		n.val = val
+		return
+	}
+	level := l.randomLevel()
+	for i := l.level; i < level; i++ {
+		update[i] = l.head
+	}
+	l.level = max(l.level, level)
+	n := &node{key: key, val: val, next: make([]*node, level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	l.len++
+}
+
+// delete 删除key，不存在时什么也不做
+func (l *skipList) delete(key int) {
+	var update [maxLevel]*node
+	n := l.findPrev(key, update[:]).next[0]
+	if n == nil || n.key != key {
+		return
+	}
+	for i := 0; i < len(n.next); i++ {
+		update[i].next[i] = n.next[i]
+	}
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+	l.len--
+}
@@ -0,0 +1,320 @@
+package interval
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	randv2 "math/rand/v2"
+	"slices"
+	"time"
+)
+
+// Item 区间树中的一个闭区间[Lo, Hi]，ID用于区分端点相同的区间
+type Item struct {
+	Lo, Hi int
+	ID     int
+}
+
+func compareItem(a, b Item) int {
+	return cmp.Or(cmp.Compare(a.Lo, b.Lo), cmp.Compare(a.Hi, b.Hi), cmp.Compare(a.ID, b.ID))
+}
+
+// treeNode 按(Lo, Hi, ID)排序的treap节点，max为子树中最大的右端点
+type treeNode struct {
+	item        Item
+	max         int
+	priority    uint64
+	left, right *treeNode
+}
+
+func (n *treeNode) update() {
+	n.max = n.item.Hi
+	if n.left != nil {
+		n.max = max(n.max, n.left.max)
+	}
+	if n.right != nil {
+		n.max = max(n.max, n.right.max)
+	}
+}
+
+// Tree 增强区间树: 以左端点为序的平衡树(treap)，每个节点额外记录子树右端点的最大值
+// 插入删除期望O(logn)，重叠查询O(logn + k)，k为结果数
+// 区间为闭区间，与merge的输入格式相同
+type Tree struct {
+	root *treeNode
+	size int
+	rng  *randv2.Rand
+}
+
+func NewTree() *Tree {
+	return &Tree{rng: randv2.New(randv2.NewPCG(3, 4))}
+}
+
+// BuildTree 由[][]int批量构建，ID为在输入中的下标
+// 排序后用单调栈一次建出笛卡尔树，O(nlogn)，比逐个插入快
+func BuildTree(intervals [][]int) *Tree {
+	t := NewTree()
+	items := make([]Item, len(intervals))
+	for i, in := range intervals {
+		items[i] = Item{Lo: in[0], Hi: in[1], ID: i}
+	}
+	slices.SortFunc(items, compareItem)
+	var stack []*treeNode
+	for _, it := range items {
+		n := &treeNode{item: it, max: it.Hi, priority: t.rng.Uint64()}
+		var last *treeNode
+		for len(stack) > 0 && stack[len(stack)-1].priority < n.priority {
+			last = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+		n.left = last
+		if len(stack) > 0 {
+			stack[len(stack)-1].right = n
+		}
+		stack = append(stack, n)
+	}
+	if len(stack) > 0 {
+		t.root = stack[0]
+	}
+	var fix func(n *treeNode)
+	fix = func(n *treeNode) {
+		if n == nil {
+			return
+		}
+		fix(n.left)
+		fix(n.right)
+		n.update()
+	}
+	fix(t.root)
+	t.size = len(items)
+	return t
+}
+
+func (t *Tree) Len() int {
+	return t.size
+}
+
+// split 按key拆成 < key 和 >= key 两棵树，inclusive为true时拆成 <= key 和 > key
+func split(n *treeNode, key Item, inclusive bool) (*treeNode, *treeNode) {
+	if n == nil {
+		return nil, nil
+	}
+	if c := compareItem(n.item, key); c < 0 || (inclusive && c == 0) {
+		l, r := split(n.right, key, inclusive)
+		n.right = l
+		n.update()
+		return n, r
+	}
+	l, r := split(n.left, key, inclusive)
+	n.left = r
+	n.update()
+	return l, n
+}
+
+// join 合并两棵树，a中所有key都小于b
+func join(a, b *treeNode) *treeNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = join(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = join(a, b.left)
+	b.update()
+	return b
+}
+
+// Insert 插入闭区间[lo, hi]，同一个(lo, hi, id)重复插入会保留一份
+func (t *Tree) Insert(lo, hi, id int) {
+	it := Item{Lo: lo, Hi: hi, ID: id}
+	l, r := split(t.root, it, false)
+	mid, r := split(r, it, true)
+	if mid == nil {
+		t.size++
+	}
+	n := &treeNode{item: it, max: hi, priority: t.rng.Uint64()}
+	t.root = join(join(l, n), r)
+}
+
+// Delete 删除区间，不存在返回false
+func (t *Tree) Delete(lo, hi, id int) bool {
+	it := Item{Lo: lo, Hi: hi, ID: id}
+	l, r := split(t.root, it, false)
+	mid, r := split(r, it, true)
+	t.root = join(l, r)
+	if mid == nil {
+		return false
+	}
+	t.size--
+	return true
+}
+
+// Visit 按左端点顺序遍历与[lo, hi]重叠的区间，f返回false时停止
+func (t *Tree) Visit(lo, hi int, f func(Item) bool) {
+	var dfs func(n *treeNode) bool
+	dfs = func(n *treeNode) bool {
+		// 子树中所有区间都在lo左侧
+		if n == nil || n.max < lo {
+			return true
+		}
+		if !dfs(n.left) {
+			return false
+		}
+		// 右子树的左端点都不小于当前节点，当前节点已在hi右侧时右子树也不会重叠
+		if n.item.Lo > hi {
+			return true
+		}
+		if n.item.Hi >= lo && !f(n.item) {
+			return false
+		}
+		return dfs(n.right)
+	}
+	dfs(t.root)
+}
+
+// Overlap 与[lo, hi]重叠的所有区间
+func (t *Tree) Overlap(lo, hi int) []Item {
+	var res []Item
+	t.Visit(lo, hi, func(it Item) bool {
+		res = append(res, it)
+		return true
+	})
+	return res
+}
+
+// Stab 包含点x的所有区间
+func (t *Tree) Stab(x int) []Item {
+	return t.Overlap(x, x)
+}
+
+// AnyOverlap 是否存在与[lo, hi]重叠的区间
+func (t *Tree) AnyOverlap(lo, hi int) bool {
+	found := false
+	t.Visit(lo, hi, func(Item) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// Items 按(Lo, Hi, ID)顺序输出所有区间
+func (t *Tree) Items() []Item {
+	res := make([]Item, 0, t.size)
+	var dfs func(n *treeNode)
+	dfs = func(n *treeNode) {
+		if n == nil {
+			return
+		}
+		dfs(n.left)
+		res = append(res, n.item)
+		dfs(n.right)
+	}
+	dfs(t.root)
+	return res
+}
+
+// linearOverlap 线性扫描，作为对照
+func linearOverlap(items []Item, lo, hi int) []Item {
+	var res []Item
+	for _, it := range items {
+		if it.Lo <= hi && lo <= it.Hi {
+			res = append(res, it)
+		}
+	}
+	return res
+}
+
+func TestIntervalTree() {
+	r := rand.New(rand.NewSource(43))
+	bad := 0
+	for round := 0; round < 200; round++ {
+		n := r.Intn(80)
+		intervals := make([][]int, n)
+		for i := range intervals {
+			lo := r.Intn(100)
+			intervals[i] = []int{lo, lo + r.Intn(20)}
+		}
+		t := BuildTree(intervals)
+		var items []Item
+		for i, in := range intervals {
+			items = append(items, Item{Lo: in[0], Hi: in[1], ID: i})
+		}
+		// 随机增删
+		for op := 0; op < 30; op++ {
+			if len(items) > 0 && r.Intn(2) == 0 {
+				k := r.Intn(len(items))
+				it := items[k]
+				items = slices.Delete(items, k, k+1)
+				if !t.Delete(it.Lo, it.Hi, it.ID) {
+					bad++
+				}
+			} else {
+				lo := r.Intn(100)
+				it := Item{Lo: lo, Hi: lo + r.Intn(20), ID: n + op}
+				items = append(items, it)
+				t.Insert(it.Lo, it.Hi, it.ID)
+			}
+			if t.Delete(-1, -1, -1) {
+				bad++
+			}
+			lo := r.Intn(120)
+			hi := lo + r.Intn(10)
+			want := linearOverlap(items, lo, hi)
+			slices.SortFunc(want, compareItem)
+			if !slices.Equal(t.Overlap(lo, hi), want) || t.AnyOverlap(lo, hi) != (len(want) > 0) {
+				bad++
+			}
+		}
+		slices.SortFunc(items, compareItem)
+		if !slices.Equal(t.Items(), items) || t.Len() != len(items) {
+			bad++
+		}
+	}
+	// ID取到math.MaxInt时拆分边界不能溢出
+	e := NewTree()
+	e.Insert(1, 2, math.MaxInt)
+	e.Insert(1, 2, math.MaxInt-1)
+	e.Insert(1, 2, math.MaxInt)
+	e.Insert(1, 3, math.MinInt)
+	if e.Len() != 3 || !e.Delete(1, 2, math.MaxInt) || e.Delete(1, 2, math.MaxInt) ||
+		!slices.Equal(e.Items(), []Item{{1, 2, math.MaxInt - 1}, {1, 3, math.MinInt}}) {
+		bad++
+	}
+	fmt.Println("mismatches:", bad)
+
+	// 100万个区间，点查询与短区间查询
+	n := 1000000
+	intervals := make([][]int, n)
+	items := make([]Item, n)
+	for i := range intervals {
+		lo := r.Intn(1 << 30)
+		intervals[i] = []int{lo, lo + r.Intn(1000)}
+		items[i] = Item{Lo: intervals[i][0], Hi: intervals[i][1], ID: i}
+	}
+	start := time.Now()
+	t := BuildTree(intervals)
+	fmt.Printf("build %d intervals: %v\n", n, time.Since(start))
+	queries := 1000
+	start = time.Now()
+	found := 0
+	for q := 0; q < queries; q++ {
+		lo := r.Intn(1 << 30)
+		found += len(t.Overlap(lo, lo+r.Intn(100000)))
+	}
+	treeTime := time.Since(start)
+	start = time.Now()
+	linearFound := 0
+	for q := 0; q < queries/100; q++ {
+		lo := r.Intn(1 << 30)
+		linearFound += len(linearOverlap(items, lo, lo+r.Intn(100000)))
+	}
+	// 线性扫描只跑1%的查询，按比例估算
+	linearTime := time.Since(start) * 100
+	fmt.Printf("%d queries: tree=%v (found %d) linear≈%v (found %d in 1%%)\n", queries, treeTime, found, linearTime, linearFound)
+}
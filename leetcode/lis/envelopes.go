@@ -0,0 +1,142 @@
+package lis
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// MaxEnvelopes 俄罗斯套娃信封(354): 宽和高都严格更大才能套进去
+func MaxEnvelopes(envelopes [][]int) int {
+	return len(EnvelopeChain(envelopes))
+}
+
+// EnvelopeChain 返回能套在一起的最多信封，从最里层到最外层
+// 按宽升序、宽相同时高降序排序后，对高求严格递增子序列；
+// 宽相同的信封高是降序的，不会被同时选中
+func EnvelopeChain(envelopes [][]int) [][]int {
+	sorted := slices.Clone(envelopes)
+	slices.SortFunc(sorted, func(a, b []int) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(b[1], a[1]))
+	})
+	idx := IndicesFunc(sorted, Strict, func(a, b []int) int { return cmp.Compare(a[1], b[1]) })
+	res := make([][]int, len(idx))
+	for i, j := range idx {
+		res[i] = sorted[j]
+	}
+	return res
+}
+
+// bruteLIS 枚举所有子集，返回最长长度和个数
+func bruteLIS(s []int, ok func(a, b int) bool) (int, int) {
+	best, count := 0, 0
+	for mask := 0; mask < 1<<len(s); mask++ {
+		n, last, valid := 0, 0, true
+		for i := range s {
+			if mask>>i&1 == 0 {
+				continue
+			}
+			if n > 0 && !ok(last, s[i]) {
+				valid = false
+				break
+			}
+			n, last = n+1, s[i]
+		}
+		if !valid {
+			continue
+		}
+		if n > best {
+			best, count = n, 1
+		} else if n == best {
+			count++
+		}
+	}
+	return best, count
+}
+
+// isChain 检查idx严格递增，且对应的元素满足ok
+func isChain(s []int, idx []int, ok func(a, b int) bool) bool {
+	for k := 1; k < len(idx); k++ {
+		if idx[k] <= idx[k-1] || !ok(s[idx[k-1]], s[idx[k]]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLIS() {
+	r := rand.New(rand.NewSource(47))
+	bad := map[string]int{}
+	modes := []struct {
+		mode Mode
+		ok   func(a, b int) bool
+	}{
+		{Strict, func(a, b int) bool { return a < b }},
+		{NonStrict, func(a, b int) bool { return a <= b }},
+	}
+	for round := 0; round < 500; round++ {
+		s := make([]int, r.Intn(13))
+		for i := range s {
+			s[i] = r.Intn(6)
+		}
+		for _, m := range modes {
+			wantLen, wantCount := bruteLIS(s, m.ok)
+			seq, idx := LIS(s, m.mode)
+			if len(idx) != wantLen || !isChain(s, idx, m.ok) {
+				bad["LIS"]++
+			}
+			for i, j := range idx {
+				if seq[i] != s[j] {
+					bad["LIS"]++
+				}
+			}
+			if n, c := Count(s, m.mode); n != wantLen || (wantLen > 0 && c != wantCount) {
+				bad["Count"]++
+			}
+		}
+		// 自定义比较: 最长递减子序列
+		desc := func(a, b int) int { return cmp.Compare(b, a) }
+		gt := func(a, b int) bool { return a > b }
+		wantLen, wantCount := bruteLIS(s, gt)
+		idx := IndicesFunc(s, Strict, desc)
+		if len(idx) != wantLen || !isChain(s, idx, gt) {
+			bad["IndicesFunc"]++
+		}
+		if n, c := CountFunc(s, Strict, desc); n != wantLen || (wantLen > 0 && c != wantCount) {
+			bad["CountFunc"]++
+		}
+
+		// 信封: O(n^2) DP对照
+		envelopes := make([][]int, r.Intn(15))
+		for i := range envelopes {
+			envelopes[i] = []int{1 + r.Intn(6), 1 + r.Intn(6)}
+		}
+		chain := EnvelopeChain(envelopes)
+		sorted := slices.Clone(envelopes)
+		slices.SortFunc(sorted, func(a, b []int) int { return cmp.Compare(a[0], b[0]) })
+		dp := make([]int, len(sorted))
+		want := 0
+		for i := range sorted {
+			dp[i] = 1
+			for j := 0; j < i; j++ {
+				if sorted[j][0] < sorted[i][0] && sorted[j][1] < sorted[i][1] {
+					dp[i] = max(dp[i], dp[j]+1)
+				}
+			}
+			want = max(want, dp[i])
+		}
+		if len(chain) != want || MaxEnvelopes(envelopes) != want {
+			bad["Envelopes"]++
+		}
+		for k := 1; k < len(chain); k++ {
+			if chain[k-1][0] >= chain[k][0] || chain[k-1][1] >= chain[k][1] {
+				bad["Envelopes"]++
+			}
+		}
+	}
+	fmt.Println("mismatches:", bad)
+	fmt.Println(LIS([]int{10, 9, 2, 5, 3, 7, 101, 18}, Strict))
+	fmt.Println(Count([]int{1, 3, 5, 4, 7}, Strict))
+	fmt.Println(EnvelopeChain([][]int{{5, 4}, {6, 4}, {6, 7}, {2, 3}}))
+}
@@ -0,0 +1,115 @@
+package lis
+
+import (
+	"cmp"
+
+	"leetcode/bsearch"
+)
+
+// Mode 递增的含义
+type Mode int
+
+const (
+	Strict    Mode = iota // 严格递增 a < b
+	NonStrict             // 非递减 a <= b
+)
+
+// less 在mode下a能否排在b之前
+func less[T any](a, b T, mode Mode, cmp func(a, b T) int) bool {
+	if mode == Strict {
+		return cmp(a, b) < 0
+	}
+	return cmp(a, b) <= 0
+}
+
+// LIS 返回一个最长递增子序列及其下标
+func LIS[T cmp.Ordered](s []T, mode Mode) ([]T, []int) {
+	idx := IndicesFunc(s, mode, cmp.Compare[T])
+	seq := make([]T, len(idx))
+	for i, j := range idx {
+		seq[i] = s[j]
+	}
+	return seq, idx
+}
+
+// Length 最长递增子序列的长度，O(nlogn)
+func Length[T cmp.Ordered](s []T, mode Mode) int {
+	return len(IndicesFunc(s, mode, cmp.Compare[T]))
+}
+
+// IndicesFunc 耐心排序: tails[L]是长度为L+1的递增子序列中结尾最小的那个的下标
+// 每个元素二分找到第一个不能接在它前面的tails并替换，prev记录前驱用于回溯，O(nlogn)
+func IndicesFunc[T any](s []T, mode Mode, cmp func(a, b T) int) []int {
+	var tails []int
+	prev := make([]int, len(s))
+	for i, x := range s {
+		p := bsearch.FirstTrue(0, len(tails), func(p int) bool {
+			return !less(s[tails[p]], x, mode, cmp)
+		})
+		prev[i] = -1
+		if p > 0 {
+			prev[i] = tails[p-1]
+		}
+		if p == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[p] = i
+		}
+	}
+	res := make([]int, len(tails))
+	if len(tails) > 0 {
+		i := tails[len(tails)-1]
+		for k := len(res) - 1; k >= 0; k-- {
+			res[k] = i
+			i = prev[i]
+		}
+	}
+	return res
+}
+
+// entry 某一堆中的一个元素，sum为这一堆中从底到它为止的方案数之和
+type entry[T any] struct {
+	value T
+	sum   int
+}
+
+// Count 最长递增子序列的长度和个数(673)，下标不同即视为不同的子序列
+func Count[T cmp.Ordered](s []T, mode Mode) (int, int) {
+	return CountFunc(s, mode, cmp.Compare[T])
+}
+
+// CountFunc 在耐心排序的每一堆中保留所有放过的元素，O(nlogn)
+// 同一堆中的元素从底到顶不递增，能接在x前面的元素是上一堆顶部连续的一段，
+// 二分找到这一段的起点，用前缀和求出以x结尾的最长子序列个数
+func CountFunc[T any](s []T, mode Mode, cmp func(a, b T) int) (int, int) {
+	var piles [][]entry[T]
+	for _, x := range s {
+		p := bsearch.FirstTrue(0, len(piles), func(p int) bool {
+			top := piles[p][len(piles[p])-1]
+			return !less(top.value, x, mode, cmp)
+		})
+		ways := 1
+		if p > 0 {
+			below := piles[p-1]
+			k := bsearch.FirstTrue(0, len(below), func(k int) bool {
+				return less(below[k].value, x, mode, cmp)
+			})
+			ways = below[len(below)-1].sum
+			if k > 0 {
+				ways -= below[k-1].sum
+			}
+		}
+		if p == len(piles) {
+			piles = append(piles, nil)
+		}
+		if n := len(piles[p]); n > 0 {
+			ways += piles[p][n-1].sum
+		}
+		piles[p] = append(piles[p], entry[T]{value: x, sum: ways})
+	}
+	if len(piles) == 0 {
+		return 0, 0
+	}
+	last := piles[len(piles)-1]
+	return len(piles), last[len(last)-1].sum
+}
@@ -0,0 +1,108 @@
+package mono
+
+// Deque 基于环形缓冲区的双端队列，容量为2的幂，满了翻倍
+// 两端的插入删除均为O(1)，不会像 s = s[1:] 那样反复重新分配
+type Deque[T any] struct {
+	buf  []T
+	head int // 队首元素在buf中的下标
+	size int
+}
+
+func NewDeque[T any](capacity int) *Deque[T] {
+	c := 1
+	for c < capacity {
+		c <<= 1
+	}
+	return &Deque[T]{buf: make([]T, c)}
+}
+
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+func (d *Deque[T]) Empty() bool {
+	return d.size == 0
+}
+
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	n := len(d.buf) * 2
+	if n == 0 {
+		n = 8
+	}
+	buf := make([]T, n)
+	for i := 0; i < d.size; i++ {
+		buf[i] = d.buf[(d.head+i)&(len(d.buf)-1)]
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[(d.head+d.size)&(len(d.buf)-1)] = v
+	d.size++
+}
+
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1) & (len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.size++
+}
+
+func (d *Deque[T]) PopFront() T {
+	if d.size == 0 {
+		panic("deque is empty")
+	}
+	var zero T
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // 释放引用
+	d.head = (d.head + 1) & (len(d.buf) - 1)
+	d.size--
+	return v
+}
+
+func (d *Deque[T]) PopBack() T {
+	if d.size == 0 {
+		panic("deque is empty")
+	}
+	var zero T
+	i := (d.head + d.size - 1) & (len(d.buf) - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	return v
+}
+
+func (d *Deque[T]) Front() T {
+	if d.size == 0 {
+		panic("deque is empty")
+	}
+	return d.buf[d.head]
+}
+
+func (d *Deque[T]) Back() T {
+	if d.size == 0 {
+		panic("deque is empty")
+	}
+	return d.buf[(d.head+d.size-1)&(len(d.buf)-1)]
+}
+
+// At 返回从队首开始的第i个元素
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.size {
+		panic("deque index out of range")
+	}
+	return d.buf[(d.head+i)&(len(d.buf)-1)]
+}
+
+func (d *Deque[T]) Clear() {
+	var zero T
+	for i := 0; i < d.size; i++ {
+		d.buf[(d.head+i)&(len(d.buf)-1)] = zero
+	}
+	d.head, d.size = 0, 0
+}
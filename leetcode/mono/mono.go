@@ -0,0 +1,204 @@
+package mono
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+)
+
+// MonoQueue 单调队列，队首始终是按less意义下最大的元素
+// Push时从队尾弹出所有比新元素小的元素，相等的元素会保留
+type MonoQueue[T any] struct {
+	d    *Deque[T]
+	less func(a, b T) bool
+}
+
+func NewMonoQueue[T any](less func(a, b T) bool) *MonoQueue[T] {
+	return &MonoQueue[T]{d: NewDeque[T](8), less: less}
+}
+
+// NewMaxQueue 队首为最大值
+func NewMaxQueue[T cmp.Ordered]() *MonoQueue[T] {
+	return NewMonoQueue(func(a, b T) bool { return a < b })
+}
+
+// NewMinQueue 队首为最小值
+func NewMinQueue[T cmp.Ordered]() *MonoQueue[T] {
+	return NewMonoQueue(func(a, b T) bool { return a > b })
+}
+
+func (q *MonoQueue[T]) Push(v T) {
+	for !q.d.Empty() && q.less(q.d.Back(), v) {
+		q.d.PopBack()
+	}
+	q.d.PushBack(v)
+}
+
+func (q *MonoQueue[T]) Front() T {
+	return q.d.Front()
+}
+
+func (q *MonoQueue[T]) PopFront() T {
+	return q.d.PopFront()
+}
+
+// Evict 弹出队首所有满足expired的元素，常用于淘汰滑出窗口的下标
+func (q *MonoQueue[T]) Evict(expired func(T) bool) {
+	for !q.d.Empty() && expired(q.d.Front()) {
+		q.d.PopFront()
+	}
+}
+
+func (q *MonoQueue[T]) Len() int {
+	return q.d.Len()
+}
+
+func (q *MonoQueue[T]) Clear() {
+	q.d.Clear()
+}
+
+// nearest 单调栈求每个元素向右(forward)或向左第一个满足found(s[j], s[i])的下标，不存在为-1
+func nearest[T any](s []T, forward bool, found func(other, cur T) bool) []int {
+	res := make([]int, len(s))
+	stack := NewDeque[int](len(s))
+	for k := range s {
+		i := k
+		if forward {
+			i = len(s) - 1 - k
+		}
+		for !stack.Empty() && !found(s[stack.Back()], s[i]) {
+			stack.PopBack()
+		}
+		if stack.Empty() {
+			res[i] = -1
+		} else {
+			res[i] = stack.Back()
+		}
+		stack.PushBack(i)
+	}
+	return res
+}
+
+// NextGreaterFunc 右侧第一个满足less(s[i], s[j])的下标j
+func NextGreaterFunc[T any](s []T, less func(a, b T) bool) []int {
+	return nearest(s, true, func(other, cur T) bool { return less(cur, other) })
+}
+
+// PrevGreaterFunc 左侧第一个满足less(s[i], s[j])的下标j
+func PrevGreaterFunc[T any](s []T, less func(a, b T) bool) []int {
+	return nearest(s, false, func(other, cur T) bool { return less(cur, other) })
+}
+
+// NextSmallerFunc 右侧第一个满足less(s[j], s[i])的下标j
+func NextSmallerFunc[T any](s []T, less func(a, b T) bool) []int {
+	return nearest(s, true, func(other, cur T) bool { return less(other, cur) })
+}
+
+// PrevSmallerFunc 左侧第一个满足less(s[j], s[i])的下标j
+func PrevSmallerFunc[T any](s []T, less func(a, b T) bool) []int {
+	return nearest(s, false, func(other, cur T) bool { return less(other, cur) })
+}
+
+// NextGreater 右侧第一个严格更大的元素下标，不存在为-1
+func NextGreater[T cmp.Ordered](s []T) []int {
+	return NextGreaterFunc(s, cmp.Less[T])
+}
+
+// PrevGreater 左侧第一个严格更大的元素下标，不存在为-1
+func PrevGreater[T cmp.Ordered](s []T) []int {
+	return PrevGreaterFunc(s, cmp.Less[T])
+}
+
+// NextSmaller 右侧第一个严格更小的元素下标，不存在为-1
+func NextSmaller[T cmp.Ordered](s []T) []int {
+	return NextSmallerFunc(s, cmp.Less[T])
+}
+
+// PrevSmaller 左侧第一个严格更小的元素下标，不存在为-1
+func PrevSmaller[T cmp.Ordered](s []T) []int {
+	return PrevSmallerFunc(s, cmp.Less[T])
+}
+
+func TestMono() {
+	r := rand.New(rand.NewSource(6))
+	bad := 0
+	// Deque与切片模拟比较
+	d := NewDeque[int](0)
+	var model []int
+	for i := 0; i < 100000; i++ {
+		switch op := r.Intn(4); {
+		case op == 0:
+			d.PushBack(i)
+			model = append(model, i)
+		case op == 1:
+			d.PushFront(i)
+			model = append([]int{i}, model...)
+		case op == 2 && len(model) > 0:
+			if d.PopFront() != model[0] {
+				bad++
+			}
+			model = model[1:]
+		case op == 3 && len(model) > 0:
+			if d.PopBack() != model[len(model)-1] {
+				bad++
+			}
+			model = model[:len(model)-1]
+		}
+		if d.Len() != len(model) || (len(model) > 0 && (d.Front() != model[0] || d.Back() != model[len(model)-1])) {
+			bad++
+		}
+	}
+
+	// 单调栈辅助函数与暴力比较
+	for round := 0; round < 500; round++ {
+		s := make([]int, r.Intn(30))
+		for i := range s {
+			s[i] = r.Intn(10)
+		}
+		check := func(got []int, step int, ok func(a, b int) bool) {
+			for i := range s {
+				want := -1
+				for j := i + step; j >= 0 && j < len(s); j += step {
+					if ok(s[j], s[i]) {
+						want = j
+						break
+					}
+				}
+				if got[i] != want {
+					bad++
+				}
+			}
+		}
+		check(NextGreater(s), 1, func(a, b int) bool { return a > b })
+		check(PrevGreater(s), -1, func(a, b int) bool { return a > b })
+		check(NextSmaller(s), 1, func(a, b int) bool { return a < b })
+		check(PrevSmaller(s), -1, func(a, b int) bool { return a < b })
+
+		// 滑动窗口最大最小值
+		k := 1 + r.Intn(5)
+		maxQ, minQ := NewMaxQueue[int](), NewMinQueue[int]()
+		for i := range s {
+			maxQ.Push(s[i])
+			minQ.Push(s[i])
+			if i >= k {
+				// 按值淘汰时相等的元素只弹出一个
+				if maxQ.Front() == s[i-k] {
+					maxQ.PopFront()
+				}
+				if minQ.Front() == s[i-k] {
+					minQ.PopFront()
+				}
+			}
+			if i >= k-1 {
+				lo, hi := s[i], s[i]
+				for j := i - k + 1; j <= i; j++ {
+					lo, hi = min(lo, s[j]), max(hi, s[j])
+				}
+				if maxQ.Front() != hi || minQ.Front() != lo {
+					bad++
+				}
+			}
+		}
+	}
+	fmt.Println("mismatches:", bad)
+}
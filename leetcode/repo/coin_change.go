@@ -1,8 +1,11 @@
 package repo
 
 import (
+	"fmt"
 	"math"
 	"sort"
+
+	"leetcode/coin"
 )
 
 func coinChange(coins []int, amount int) int {
@@ -29,3 +32,28 @@ func coinChange(coins []int, amount int) int {
 	}
 	return dp[amount]
 }
+
+// 返回凑出amount用到的硬币，凑不出返回nil
+func coinChangeCoins(coins []int, amount int) []int {
+	res, _ := coin.MinCoins(coins, amount)
+	return res
+}
+
+// 518. 零钱兑换 II
+func change(amount int, coins []int) int {
+	n, _ := coin.Combinations(coins, amount)
+	return n
+}
+
+// 377. 组合总和 Ⅳ
+func combinationSum4(nums []int, target int) int {
+	n, _ := coin.Permutations(nums, target)
+	return n
+}
+
+func TestCoinChange() {
+	fmt.Println(coinChange([]int{1, 2, 5}, 11), coinChangeCoins([]int{1, 2, 5}, 11))
+	fmt.Println(coinChange([]int{2}, 3), coinChangeCoins([]int{2}, 3))
+	fmt.Println(change(5, []int{1, 2, 5}), change(3, []int{2}), change(10, []int{10}))
+	fmt.Println(combinationSum4([]int{1, 2, 3}, 4), combinationSum4([]int{9}, 3))
+}
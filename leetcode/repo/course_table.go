@@ -1,5 +1,7 @@
 package repo
 
+import "leetcode/graph"
+
 func canFinish(numCourses int, prerequisites [][]int) bool {
 	prevMap := map[int][]int{}
 	// 寻找是否存在环,三色判断, 未学0，正在学1，已学2
@@ -31,3 +33,12 @@ func canFinish(numCourses int, prerequisites [][]int) bool {
 	}
 	return true
 }
+
+// 课程表 II: 返回一个可行的修课顺序，不存在时返回空数组
+func findOrder(numCourses int, prerequisites [][]int) []int {
+	order, ok := graph.TopoSortKahn(graph.FromPrerequisites(numCourses, prerequisites))
+	if !ok {
+		return []int{}
+	}
+	return order
+}
@@ -1,19 +1,15 @@
 package repo
 
+import "leetcode/mono"
+
 func dailyTemperatures(temperatures []int) []int {
-	//单调栈
-	stack := []int{}
+	// 单调栈: 右侧第一个更高的温度
+	next := mono.NextGreater(temperatures)
 	res := make([]int, len(temperatures))
-	for i := len(temperatures) - 1; i >= 0; i-- {
-		for len(stack) > 0 && temperatures[stack[len(stack)-1]] <= temperatures[i] {
-			stack = stack[:len(stack)-1]
-		}
-		if len(stack) == 0 {
-			res[i] = 0
-		} else {
-			res[i] = stack[len(stack)-1] - i
+	for i, j := range next {
+		if j != -1 {
+			res[i] = j - i
 		}
-		stack = append(stack, i)
 	}
 	return res
 }
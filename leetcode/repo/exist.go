@@ -1,35 +1,35 @@
 package repo
 
+import "leetcode/grid"
+
 func exist(board [][]byte, word string) bool {
-	m, n := len(board), len(board[0])
-	startRow, startCol := []int{}, []int{}
-	for i := range board {
-		for j := range board[0] {
-			if board[i][j] == word[0] {
-				startRow = append(startRow, i)
-				startCol = append(startCol, j)
-			}
-		}
+	if len(board) == 0 || len(board[0]) == 0 || len(word) == 0 {
+		return len(word) == 0
 	}
-	var dfs func(i, j int, idx int)
-	visited := make([]bool, m*n)
-	direct := [][]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
-	var res bool
-	dfs = func(i, j int, idx int) {
-		if idx == len(word) || res {
-			res = true
-			return
+	g := grid.From(board)
+	visited := make([]bool, g.Rows()*g.Cols())
+	// 回溯的深度不超过len(word)
+	var dfs func(p grid.Point, idx int) bool
+	dfs = func(p grid.Point, idx int) bool {
+		if visited[g.Index(p)] || g.At(p) != word[idx] {
+			return false
 		}
-		if i >= 0 && i < len(board) && j >= 0 && j < len(board[0]) && !visited[i*n+j] && board[i][j] == word[idx] {
-			visited[i*n+j] = true
-			for _, d := range direct {
-				dfs(i+d[0], j+d[1], idx+1)
+		if idx == len(word)-1 {
+			return true
+		}
+		visited[g.Index(p)] = true
+		for q := range g.Neighbors4(p) {
+			if dfs(q, idx+1) {
+				return true
 			}
-			visited[i*n+j] = false
 		}
+		visited[g.Index(p)] = false
+		return false
 	}
-	for i := range startCol {
-		dfs(startRow[i], startCol[i], 0)
+	for p := range g.All() {
+		if dfs(p, 0) {
+			return true
+		}
 	}
-	return res
+	return false
 }
@@ -1,33 +1,71 @@
 package repo
 
-// dfs扩散
-func numIslands(grid [][]byte) int {
-	visit := make([]bool, len(grid)*len(grid[0]))
-	w := len(grid[0])
-	var res int
-	var dir = [][]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
-	var dfs func(i, j int)
-	dfs = func(i, j int) {
-		if i >= len(grid) || i < 0 || j < 0 || j >= len(grid[0]) || grid[i][j] == '0' {
-			return
-		}
-		if visit[i*w+j] {
-			return
-		}
-		visit[i*w+j] = true
-		for _, d := range dir {
-			dfs(i+d[0], j+d[1])
-		}
-		return
+import (
+	"fmt"
+	"math/rand"
+
+	"leetcode/grid"
+)
+
+// 迭代的连通分量标记，全是陆地的大网格也不会栈溢出
+func numIslands(g [][]byte) int {
+	if len(g) == 0 || len(g[0]) == 0 {
+		return 0
+	}
+	_, res := grid.Label(grid.From(g), func(b byte) bool { return b == '1' }, grid.Dir4)
+	return res
+}
+
+// 分块并行的版本，适合上万行列的大网格，workers<=0时使用GOMAXPROCS
+func numIslandsParallel(g [][]byte, workers int) int {
+	if len(g) == 0 || len(g[0]) == 0 {
+		return 0
+	}
+	_, res := grid.LabelParallel(grid.From(g), func(b byte) bool { return b == '1' }, grid.Dir4, 512, workers)
+	return res
+}
+
+// 岛屿数量 II: 依次加入陆地，返回每次加入后的岛屿数量
+func numIslands2(m int, n int, positions [][]int) []int {
+	ic := grid.NewIslandCounter(m, n)
+	res := make([]int, len(positions))
+	for i, p := range positions {
+		res[i] = ic.AddLand(p[0], p[1])
 	}
+	return res
+}
 
-	for i := range grid {
-		for j := range grid[i] {
-			if grid[i][j] == '1' && !visit[i*w+j] {
-				res++
-				dfs(i, j)
+// 随机加减陆地，每一步都与从头计算的numIslands比较
+func TestNumIslands2() {
+	fmt.Println(numIslands2(3, 3, [][]int{{0, 0}, {0, 1}, {1, 2}, {2, 1}}))
+
+	r := rand.New(rand.NewSource(4))
+	bad := 0
+	for round := 0; round < 50; round++ {
+		m, n := 1+r.Intn(12), 1+r.Intn(12)
+		g := make([][]byte, m)
+		for i := range g {
+			g[i] = make([]byte, n)
+			for j := range g[i] {
+				g[i][j] = '0'
+			}
+		}
+		ic := grid.NewIslandCounter(m, n)
+		for step := 0; step < 3000; step++ {
+			i, j := r.Intn(m), r.Intn(n)
+			var got int
+			// 前200步多加陆地，之后加减各半
+			if r.Intn(2) == 0 || (step < 200 && r.Intn(2) == 0) {
+				g[i][j] = '1'
+				got = ic.AddLand(i, j)
+			} else {
+				g[i][j] = '0'
+				got = ic.RemoveLand(i, j)
+			}
+			if got != numIslands(g) {
+				bad++
 			}
 		}
 	}
-	return res
+	fmt.Println("mismatches:", bad)
 }
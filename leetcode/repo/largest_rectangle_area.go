@@ -1,39 +1,19 @@
 package repo
 
+import "leetcode/mono"
+
 func largestRectangleArea(heights []int) int {
 	// 枚举每一个柱子的高度和左右边界
-	fromLeft := make([]int, len(heights))
-	stack := []int{}
-	for i, h := range heights {
-		// 保留单调递增的栈
-		for len(stack) > 0 && h <= heights[stack[len(stack)-1]] {
-			stack = stack[:len(stack)-1]
-		}
-		if len(stack) == 0 {
-			fromLeft[i] = 0
-		} else {
-			fromLeft[i] = stack[len(stack)-1] + 1
-		}
-		stack = append(stack, i)
-	}
-
-	fromRight := make([]int, len(heights))
-	stack = []int{}
-	for i := len(heights) - 1; i >= 0; i-- {
-		for len(stack) > 0 && heights[i] <= heights[stack[len(stack)-1]] {
-			stack = stack[:len(stack)-1]
-		}
-		if len(stack) == 0 {
-			fromRight[i] = len(heights) - 1
-		} else {
-			fromRight[i] = stack[len(stack)-1] - 1
-		}
-		stack = append(stack, i)
-	}
+	// 左右两侧第一个更矮的柱子之间的区域都不低于当前柱子
+	left := mono.PrevSmaller(heights)
+	right := mono.NextSmaller(heights)
 	var res int
-
 	for i, h := range heights {
-		res = max(res, h*(fromRight[i]-fromLeft[i]+1))
+		r := right[i]
+		if r == -1 {
+			r = len(heights)
+		}
+		res = max(res, h*(r-left[i]-1))
 	}
 	return res
 }
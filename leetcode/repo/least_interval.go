@@ -1,5 +1,14 @@
 package repo
 
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"leetcode/scheduler"
+)
+
 func leastInterval(tasks []byte, n int) int {
 	// 贪心
 	// 时间取决于两者
@@ -20,3 +29,44 @@ func leastInterval(tasks []byte, n int) int {
 	return max((n+1)*maxCounter-n+count, len(tasks))
 
 }
+
+// 用公式作为校验，比较调度器生成的实际执行顺序
+func TestLeastInterval() {
+	tasks := []byte("AAABBBCCD")
+	n := 2
+	s := scheduler.Plan(scheduler.FromBytes(tasks, n), 1)
+	fmt.Print(s)
+	fmt.Println(s.Len(), leastInterval(tasks, n))
+
+	r := rand.New(rand.NewSource(3))
+	bad := 0
+	for round := 0; round < 500; round++ {
+		tasks = make([]byte, 1+r.Intn(40))
+		for i := range tasks {
+			tasks[i] = byte('A' + r.Intn(1+r.Intn(26)))
+		}
+		n = r.Intn(6)
+		list := scheduler.FromBytes(tasks, n)
+		s = scheduler.Plan(list, 1)
+		if s.Len() != leastInterval(tasks, n) || scheduler.Validate(s, list) != nil {
+			bad++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+
+	runner := &scheduler.Runner{Workers: 2, DefaultCooldown: 1, Tick: time.Millisecond}
+	var mu sync.Mutex
+	var order []string
+	var jobs []scheduler.Job
+	for _, t := range "AAABBC" {
+		name := string(t)
+		jobs = append(jobs, scheduler.Job{Task: name, Run: func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}})
+	}
+	s, err := runner.Run(jobs)
+	fmt.Print(s)
+	fmt.Println(len(order), err)
+}
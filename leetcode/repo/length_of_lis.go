@@ -1,21 +1,56 @@
 package repo
 
+import (
+	"fmt"
+	"math/rand"
+
+	"leetcode/lis"
+)
+
 func lengthOfLIS(nums []int) int {
 	// dp[i]表示以nums[i]结尾的最长严格递增子序列长度
 	dp := make([]int, len(nums))
 	var res int
 	for i := range dp {
 		dp[i] = 1
-		if i == 0 {
-			res = max(res, dp[i])
-			continue
-		}
-		for j := 0; j <= i; j++ {
+		for j := 0; j < i; j++ {
 			if nums[i] > nums[j] {
 				dp[i] = max(dp[i], dp[j]+1)
 			}
-			res = max(res, dp[i])
 		}
+		res = max(res, dp[i])
 	}
 	return res
 }
+
+// 耐心排序，O(nlogn)
+func lengthOfLIS2(nums []int) int {
+	return lis.Length(nums, lis.Strict)
+}
+
+// 673. 最长递增子序列的个数
+func findNumberOfLIS(nums []int) int {
+	_, count := lis.Count(nums, lis.Strict)
+	return count
+}
+
+// 354. 俄罗斯套娃信封问题
+func maxEnvelopes(envelopes [][]int) int {
+	return lis.MaxEnvelopes(envelopes)
+}
+
+func TestLengthOfLIS() {
+	r := rand.New(rand.NewSource(47))
+	bad := 0
+	for round := 0; round < 300; round++ {
+		nums := make([]int, r.Intn(30))
+		for i := range nums {
+			nums[i] = r.Intn(10)
+		}
+		if lengthOfLIS(nums) != lengthOfLIS2(nums) {
+			bad++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+	fmt.Println(findNumberOfLIS([]int{2, 2, 2, 2, 2}), maxEnvelopes([][]int{{5, 4}, {6, 4}, {6, 7}, {2, 3}}))
+}
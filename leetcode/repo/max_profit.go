@@ -1,15 +1,33 @@
 package repo
 
-import "math"
+import (
+	"fmt"
 
+	"leetcode/stock"
+)
+
+// 121. 只能买卖一次
 func maxProfit(prices []int) int {
-	curLow := math.MaxInt32
-	res := 0
-	for _, n := range prices {
-		if n < curLow {
-			curLow = n
-		}
-		res = max(res, n-curLow)
+	return stock.Optimize(prices, stock.Constraints[int]{MaxTransactions: 1}).Profit
+}
+
+// 123/188. 最多k次交易
+func maxProfitK(k int, prices []int) int {
+	if k <= 0 {
+		return 0
 	}
-	return res
+	return stock.Optimize(prices, stock.Constraints[int]{MaxTransactions: k}).Profit
+}
+
+// 714. 含手续费
+func maxProfitWithFee(prices []int, fee int) int {
+	return stock.Optimize(prices, stock.Constraints[int]{Fee: fee}).Profit
+}
+
+func TestMaxProfit() {
+	fmt.Println(maxProfit([]int{7, 1, 5, 3, 6, 4}))           // 5
+	fmt.Println(maxProfit2([]int{7, 1, 5, 3, 6, 4}))          // 7
+	fmt.Println(maxProfit3([]int{1, 2, 3, 0, 2}))             // 3
+	fmt.Println(maxProfitK(2, []int{3, 3, 5, 0, 0, 3, 1, 4})) // 6
+	fmt.Println(maxProfitWithFee([]int{1, 3, 2, 8, 4, 9}, 2)) // 8
 }
@@ -1,25 +1,20 @@
 package repo
 
-import "fmt"
+import (
+	"fmt"
+
+	"leetcode/mono"
+)
 
 func maxSlidingWindow(nums []int, k int) []int {
-	// 单调栈 + 滑动窗口
-	var monoStack []int
-	for i := 0; i < k-1; i++ {
-		for len(monoStack) > 0 && nums[i] > monoStack[len(monoStack)-1] {
-			monoStack = monoStack[:len(monoStack)-1]
-		}
-		monoStack = append(monoStack, nums[i])
-	}
+	// 单调队列 + 滑动窗口，队列中存下标，队首为窗口内最大值
+	q := mono.NewMonoQueue(func(a, b int) bool { return nums[a] < nums[b] })
 	var res []int
-	for i := k - 1; i < len(nums); i++ {
-		for len(monoStack) > 0 && nums[i] > monoStack[len(monoStack)-1] {
-			monoStack = monoStack[:len(monoStack)-1]
-		}
-		monoStack = append(monoStack, nums[i])
-		res = append(res, monoStack[0])
-		if nums[i-k+1] == monoStack[0] {
-			monoStack = monoStack[1:]
+	for i := range nums {
+		q.Push(i)
+		q.Evict(func(j int) bool { return j <= i-k })
+		if i >= k-1 {
+			res = append(res, nums[q.Front()])
 		}
 	}
 	return res
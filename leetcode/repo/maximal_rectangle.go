@@ -1,6 +1,10 @@
 package repo
 
 func maximalRectangle(matrix [][]byte) int {
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return 0
+	}
+	// 逐行累积高度，每一行都是一次柱状图中的最大矩形
 	var res int
 	curHeight := make([]int, len(matrix[0]))
 
@@ -1,26 +1,27 @@
 package repo
 
+import "leetcode/grid"
+
 func maximalSquare(matrix [][]byte) int {
 	if len(matrix) == 0 || len(matrix[0]) == 0 {
 		return 0
 	}
-	maxArea := 0
-	dp := make([][]int, len(matrix))
-	for i := range dp {
-		dp[i] = make([]int, len(matrix[0])) // dp[i][j]表示以i,j为右下角坐标的正方形的边长
-	}
-	for i := range matrix {
-		for j := range matrix[i] {
-			if matrix[i][j] == '1' {
-				if i == 0 || j == 0 {
-					dp[i][j] = 1
-					maxArea = max(maxArea, dp[i][j])
-					continue
-				}
-				dp[i][j] = min(min(dp[i-1][j], dp[i][j-1]), dp[i-1][j-1]) + 1
-			}
-			maxArea = max(maxArea, dp[i][j])
+	g := grid.From(matrix)
+	maxSide := 0
+	dp := grid.New[int](g.Rows(), g.Cols()) // dp[i][j]表示以i,j为右下角坐标的正方形的边长
+	for p, v := range g.All() {
+		if v != '1' {
+			continue
+		}
+		if p.R == 0 || p.C == 0 {
+			dp.Set(p, 1)
+		} else {
+			up := dp.At(grid.Point{R: p.R - 1, C: p.C})
+			left := dp.At(grid.Point{R: p.R, C: p.C - 1})
+			diag := dp.At(grid.Point{R: p.R - 1, C: p.C - 1})
+			dp.Set(p, min(up, left, diag)+1)
 		}
+		maxSide = max(maxSide, dp.At(p))
 	}
-	return maxArea * maxArea
+	return maxSide * maxSide
 }
@@ -1,28 +1,22 @@
 package repo
 
-func minPathSum(grid [][]int) int {
+import "leetcode/grid"
+
+func minPathSum(g [][]int) int {
 	// dp[i][j]表示到达grid[i][j]的最小路径
-	dp := make([][]int, len(grid))
-	for i := range dp {
-		dp[i] = make([]int, len(grid[0]))
-	}
-	dp[0][0] = grid[0][0]
-	for i := range grid {
-		if i == 0 {
-			continue
-		}
-		dp[i][0] = dp[i-1][0] + grid[i][0]
-	}
-	for i := range grid[0] {
-		if i == 0 {
-			continue
-		}
-		dp[0][i] = dp[0][i-1] + grid[0][i]
-	}
-	for i := 1; i < len(dp); i++ {
-		for j := 1; j < len(dp[0]); j++ {
-			dp[i][j] = min(dp[i-1][j], dp[i][j-1]) + grid[i][j]
+	cost := grid.From(g)
+	dp := grid.New[int](cost.Rows(), cost.Cols())
+	for p, v := range cost.All() {
+		switch {
+		case p.R == 0 && p.C == 0:
+			dp.Set(p, v)
+		case p.R == 0:
+			dp.Set(p, dp.At(grid.Point{R: 0, C: p.C - 1})+v)
+		case p.C == 0:
+			dp.Set(p, dp.At(grid.Point{R: p.R - 1, C: 0})+v)
+		default:
+			dp.Set(p, min(dp.At(grid.Point{R: p.R - 1, C: p.C}), dp.At(grid.Point{R: p.R, C: p.C - 1}))+v)
 		}
 	}
-	return dp[len(dp)-1][len(dp[0])-1]
+	return dp.At(grid.Point{R: dp.Rows() - 1, C: dp.Cols() - 1})
 }
@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"fmt"
+
+	"leetcode/interval"
+)
+
+// 729. 我的日程安排表 I: 已预订的时间段放在区间集合中，与之重叠就不能预订
+type MyCalendar struct {
+	booked *interval.IntervalSet
+}
+
+func NewMyCalendar() MyCalendar {
+	return MyCalendar{booked: interval.New(interval.HalfOpen)}
+}
+
+func (this *MyCalendar) Book(startTime int, endTime int) bool {
+	if this.booked.Overlaps(startTime, endTime) {
+		return false
+	}
+	this.booked.Add(startTime, endTime)
+	return true
+}
+
+// 731. 我的日程安排表 II: once为至少预订一次的时间，twice为已经预订两次的时间
+// 与twice重叠会导致三重预订；否则新日程与once的交集成为新的二次预订
+type MyCalendarTwo struct {
+	once, twice *interval.IntervalSet
+}
+
+func NewMyCalendarTwo() MyCalendarTwo {
+	return MyCalendarTwo{once: interval.New(interval.HalfOpen), twice: interval.New(interval.HalfOpen)}
+}
+
+func (this *MyCalendarTwo) Book(startTime int, endTime int) bool {
+	if this.twice.Overlaps(startTime, endTime) {
+		return false
+	}
+	for _, in := range this.once.Covered(startTime, endTime) {
+		this.twice.Add(in[0], in[1])
+	}
+	this.once.Add(startTime, endTime)
+	return true
+}
+
+func TestMyCalendar() {
+	c1 := NewMyCalendar()
+	for _, b := range [][]int{{10, 20}, {15, 25}, {20, 30}} {
+		fmt.Print(c1.Book(b[0], b[1]), " ") // true false true
+	}
+	fmt.Println()
+	c2 := NewMyCalendarTwo()
+	for _, b := range [][]int{{10, 20}, {50, 60}, {10, 40}, {5, 15}, {5, 10}, {25, 55}} {
+		fmt.Print(c2.Book(b[0], b[1]), " ") // true true true false true true
+	}
+	fmt.Println()
+}
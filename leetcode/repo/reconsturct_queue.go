@@ -1,8 +1,42 @@
 package repo
 
-import "sort"
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
 
+	"leetcode/structure"
+)
+
+// 按身高从低到高放置: 比当前矮的人都已放好，对当前的人不可见，
+// 所以他应该站在第k+1个空位上(k为前面更高或一样高的人数)
+// 同样高的人k大的先放，用树状数组记录空位，找第k+1个空位O(logn)，总体O(nlogn)
 func reconstructQueue(people [][]int) [][]int {
+	sort.Slice(people, func(i, j int) bool {
+		if people[i][0] == people[j][0] {
+			return people[i][1] > people[j][1]
+		}
+		return people[i][0] < people[j][0]
+	})
+	n := len(people)
+	free := make([]int, n)
+	for i := range free {
+		free[i] = 1
+	}
+	slots := structure.NewFenwickFrom(free)
+	res := make([][]int, n)
+	for _, peo := range people {
+		pos := slots.FindKth(peo[1] + 1)
+		res[pos] = peo
+		slots.Add(pos, -1)
+	}
+	return res
+}
+
+// 原来的做法: 从高到低依次插入到第k个位置，插入需要移动元素，O(n^2)
+func reconstructQueueInsert(people [][]int) [][]int {
 	// 先按照身高排序，从高到低
 	sort.Slice(people, func(i, j int) bool {
 		if people[i][0] == people[j][0] {
@@ -25,3 +59,54 @@ func reconstructQueue(people [][]int) [][]int {
 	return people
 
 }
+
+// randomQueue 随机生成一个队列，返回打乱后的输入
+func randomQueue(r *rand.Rand, n, heights int) [][]int {
+	queue := make([]int, n)
+	for i := range queue {
+		queue[i] = r.Intn(heights)
+	}
+	people := make([][]int, n)
+	for i, h := range queue {
+		k := 0
+		for _, prev := range queue[:i] {
+			if prev >= h {
+				k++
+			}
+		}
+		people[i] = []int{h, k}
+	}
+	r.Shuffle(n, func(i, j int) { people[i], people[j] = people[j], people[i] })
+	return people
+}
+
+func TestReconstructQueue() {
+	fmt.Println(reconstructQueue([][]int{{7, 0}, {4, 4}, {7, 1}, {5, 0}, {6, 1}, {5, 2}}))
+	r := rand.New(rand.NewSource(46))
+	equal := func(a, b [][]int) bool { return slices.EqualFunc(a, b, slices.Equal[[]int]) }
+	bad := 0
+	for round := 0; round < 300; round++ {
+		people := randomQueue(r, r.Intn(60), 1+r.Intn(10))
+		want := reconstructQueueInsert(slices.Clone(people))
+		if !equal(reconstructQueue(slices.Clone(people)), want) {
+			bad++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+
+	// 10^5人: 身高取0..n-1的随机排列，用树状数组统计前面更高的人数来构造k
+	n := 100000
+	people := make([][]int, n)
+	shorter := structure.NewFenwick[int](n)
+	for i, h := range r.Perm(n) {
+		people[i] = []int{h, i - shorter.PrefixSum(h)}
+		shorter.Add(h, 1)
+	}
+	r.Shuffle(n, func(i, j int) { people[i], people[j] = people[j], people[i] })
+	start := time.Now()
+	fast := reconstructQueue(slices.Clone(people))
+	fastTime := time.Since(start)
+	start = time.Now()
+	slow := reconstructQueueInsert(slices.Clone(people))
+	fmt.Printf("n=%d fenwick=%v insert=%v equal=%v\n", n, fastTime, time.Since(start), equal(fast, slow))
+}
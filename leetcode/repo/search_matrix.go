@@ -1,13 +1,20 @@
 package repo
 
+import "leetcode/bsearch"
+
+// 每行、每列分别递增(240): 第一列递增，首元素大于target的行可以排除；最后一列递增，末元素小于target的行也可以排除
+// 剩下的行逐行二分，O(m*logn)
 func searchMatrix(matrix [][]int, target int) bool {
-	i, j := 0, len(matrix[0])-1
-	for i < len(matrix) && j >= 0 && matrix[i][j] != target {
-		if matrix[i][j] > target {
-			j--
-		} else {
-			i++
+	if len(matrix) == 0 || len(matrix[0]) == 0 {
+		return false
+	}
+	n := len(matrix[0])
+	lo := bsearch.LowerBoundFunc(matrix, target, func(row []int, t int) int { return row[n-1] - t })
+	hi := bsearch.UpperBoundFunc(matrix, target, func(row []int, t int) int { return row[0] - t })
+	for _, row := range matrix[lo:max(lo, hi)] {
+		if bsearch.Search(row, target) >= 0 {
+			return true
 		}
 	}
-	return i < len(matrix) && j >= 0 && matrix[i][j] == target
+	return false
 }
@@ -1,5 +1,13 @@
 package repo
 
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+)
+
 // 归并排序
 func sortList(head *ListNode) *ListNode {
 	if head == nil || head.Next == nil {
@@ -49,3 +57,219 @@ func mergeList(h1, h2 *ListNode) *ListNode {
 	}
 	return dummy.Next
 }
+
+// 自底向上归并排序，迭代实现，额外空间O(1)
+func sortListBottomUp(head *ListNode) *ListNode {
+	return sortListFunc(head, cmp.Compare[int])
+}
+
+// sortListFunc 按比较函数排序，稳定: 相等的节点保持原有顺序
+func sortListFunc(head *ListNode, cmp func(a, b int) int) *ListNode {
+	n := 0
+	for cur := head; cur != nil; cur = cur.Next {
+		n++
+	}
+	dummy := &ListNode{Next: head}
+	// 每一轮将相邻两段长度为step的有序链表合并
+	for step := 1; step < n; step <<= 1 {
+		prev, cur := dummy, dummy.Next
+		for cur != nil {
+			left := cur
+			right := cutList(left, step)
+			cur = cutList(right, step)
+			h, t := mergeListFunc(left, right, cmp)
+			prev.Next = h
+			prev = t
+		}
+	}
+	return dummy.Next
+}
+
+// cutList 保留head开始的n个节点，断开并返回之后的部分
+func cutList(head *ListNode, n int) *ListNode {
+	for ; head != nil && n > 1; n-- {
+		head = head.Next
+	}
+	if head == nil {
+		return nil
+	}
+	rest := head.Next
+	head.Next = nil
+	return rest
+}
+
+// mergeListFunc 合并两个有序链表，返回头和尾，相等时先取h1保证稳定
+func mergeListFunc(h1, h2 *ListNode, cmp func(a, b int) int) (*ListNode, *ListNode) {
+	dummy := &ListNode{}
+	cur := dummy
+	for h1 != nil && h2 != nil {
+		if cmp(h2.Val, h1.Val) < 0 {
+			cur.Next = h2
+			h2 = h2.Next
+		} else {
+			cur.Next = h1
+			h1 = h1.Next
+		}
+		cur = cur.Next
+	}
+	if h1 != nil {
+		cur.Next = h1
+	} else {
+		cur.Next = h2
+	}
+	for cur.Next != nil {
+		cur = cur.Next
+	}
+	return dummy.Next, cur
+}
+
+// 插入排序，稳定。记录已排序部分的尾节点，基本有序时大部分节点直接接在尾部，接近O(n)
+func insertionSortList(head *ListNode) *ListNode {
+	if head == nil {
+		return nil
+	}
+	dummy := &ListNode{Next: head}
+	tail := head
+	var last *ListNode
+	for tail.Next != nil {
+		node := tail.Next
+		if node.Val >= tail.Val {
+			tail = node
+			continue
+		}
+		tail.Next = node.Next
+		// 插到最后一个不大于它的节点之后。若不小于上次插入的节点就从那里开始找，
+		// 前面出现一个很大的元素时，后面的节点都插在它之前，不用每次从头找
+		prev := dummy
+		if last != nil && last.Val <= node.Val {
+			prev = last
+		}
+		for prev.Next.Val <= node.Val {
+			prev = prev.Next
+		}
+		node.Next = prev.Next
+		prev.Next = node
+		last = node
+	}
+	return dummy.Next
+}
+
+// 分治合并k个链表: 每一轮两两合并，共logk轮，不需要堆
+func mergeKListsDivide(lists []*ListNode) *ListNode {
+	if len(lists) == 0 {
+		return nil
+	}
+	lists = slices.Clone(lists)
+	for interval := 1; interval < len(lists); interval <<= 1 {
+		for i := 0; i+interval < len(lists); i += 2 * interval {
+			lists[i] = mergeList(lists[i], lists[i+interval])
+		}
+	}
+	return lists[0]
+}
+
+func listValues(head *ListNode) []int {
+	var res []int
+	for ; head != nil; head = head.Next {
+		res = append(res, head.Val)
+	}
+	return res
+}
+
+func TestSortList() {
+	r := rand.New(rand.NewSource(40))
+	// 正确性与稳定性: 按Val/10排序，个位记录原来的顺序
+	bad := 0
+	for round := 0; round < 200; round++ {
+		n := r.Intn(60)
+		nums := make([]int, n)
+		for i := range nums {
+			nums[i] = r.Intn(10)*10 + i%10
+		}
+		want := slices.Clone(nums)
+		slices.Sort(want)
+		for _, f := range []func(*ListNode) *ListNode{sortList, sortListBottomUp, insertionSortList} {
+			if !slices.Equal(listValues(f(GenerateListNode(nums))), want) {
+				bad++
+			}
+		}
+		byTens := func(a, b int) int { return cmp.Compare(a/10, b/10) }
+		stable := slices.Clone(nums)
+		slices.SortStableFunc(stable, byTens)
+		if !slices.Equal(listValues(sortListFunc(GenerateListNode(nums), byTens)), stable) {
+			bad++
+		}
+		k := r.Intn(8)
+		lists := make([]*ListNode, k)
+		lists2 := make([]*ListNode, k)
+		var all []int
+		for i := range lists {
+			part := make([]int, r.Intn(10))
+			for j := range part {
+				part[j] = r.Intn(50)
+			}
+			slices.Sort(part)
+			all = append(all, part...)
+			lists[i], lists2[i] = GenerateListNode(part), GenerateListNode(part)
+		}
+		slices.Sort(all)
+		if !slices.Equal(listValues(mergeKLists(lists)), all) || !slices.Equal(listValues(mergeKListsDivide(lists2)), all) {
+			bad++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+
+	// 耗时
+	n := 200000
+	random := make([]int, n)
+	nearly := make([]int, n)
+	for i := range random {
+		random[i] = r.Int()
+		nearly[i] = i
+	}
+	// 基本有序: 少量随机交换
+	for i := 0; i < n/1000; i++ {
+		a, b := r.Intn(n), r.Intn(n)
+		nearly[a], nearly[b] = nearly[b], nearly[a]
+	}
+	for _, input := range []struct {
+		name string
+		nums []int
+	}{{"random", random}, {"nearly", nearly}} {
+		for _, alg := range []struct {
+			name string
+			f    func(*ListNode) *ListNode
+		}{{"top-down", sortList}, {"bottom-up", sortListBottomUp}, {"insertion", insertionSortList}} {
+			// 随机输入上插入排序是O(n^2)，只测一小段
+			nums := input.nums
+			if alg.name == "insertion" && input.name == "random" {
+				nums = nums[:5000]
+			}
+			head := GenerateListNode(nums)
+			start := time.Now()
+			head = alg.f(head)
+			fmt.Printf("%-7s n=%-7d %-10s %-14v sorted=%v\n", input.name, len(nums), alg.name, time.Since(start), slices.IsSorted(listValues(head)))
+		}
+	}
+	for _, k := range []int{10, 1000} {
+		per := n / k
+		lists := make([]*ListNode, k)
+		lists2 := make([]*ListNode, k)
+		for i := range lists {
+			part := make([]int, per)
+			for j := range part {
+				part[j] = r.Int()
+			}
+			slices.Sort(part)
+			lists[i], lists2[i] = GenerateListNode(part), GenerateListNode(part)
+		}
+		start := time.Now()
+		h1 := mergeKLists(lists)
+		heapTime := time.Since(start)
+		start = time.Now()
+		h2 := mergeKListsDivide(lists2)
+		divideTime := time.Since(start)
+		fmt.Printf("k=%-5d heap=%-14v divide=%-14v sorted=%v\n", k, heapTime, divideTime,
+			slices.IsSorted(listValues(h1)) && slices.IsSorted(listValues(h2)))
+	}
+}
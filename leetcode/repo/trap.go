@@ -1,20 +1,21 @@
 package repo
 
+import "leetcode/mono"
+
 func trap(height []int) int {
-	leftMax := make([]int, len(height))
-	rightMax := make([]int, len(height))
-	n := len(height)
-	leftMax[0] = height[0]
-	rightMax[n-1] = height[n-1]
-	for i := 1; i < n; i++ {
-		leftMax[i] = max(leftMax[i-1], height[i])
-	}
-	for i := n - 2; i >= 0; i-- {
-		rightMax[i] = max(rightMax[i+1], height[i])
-	}
+	// 单调递减栈，遇到更高的柱子时，栈顶与新的栈顶、当前柱子围成一层积水
+	stack := mono.NewDeque[int](len(height))
 	var res int
 	for i, h := range height {
-		res += min(leftMax[i], rightMax[i]) - h
+		for !stack.Empty() && height[stack.Back()] < h {
+			bottom := stack.PopBack()
+			if stack.Empty() {
+				break
+			}
+			left := stack.Back()
+			res += (i - left - 1) * (min(height[left], h) - height[bottom])
+		}
+		stack.PushBack(i)
 	}
 	return res
 }
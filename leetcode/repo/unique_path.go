@@ -1,20 +1,15 @@
 package repo
 
+import "leetcode/grid"
+
 func uniquePaths(m int, n int) int {
-	dp := make([][]int, m)
-	for i := range dp {
-		dp[i] = make([]int, n)
-	}
-	for i := range dp {
-		dp[i][0] = 1
-	}
-	for j := range dp[0] {
-		dp[0][j] = 1
-	}
-	for i := 1; i < m; i++ {
-		for j := 1; j < n; j++ {
-			dp[i][j] = dp[i-1][j] + dp[i][j-1]
+	dp := grid.New[int](m, n)
+	for p := range dp.All() {
+		if p.R == 0 || p.C == 0 {
+			dp.Set(p, 1)
+			continue
 		}
+		dp.Set(p, dp.At(grid.Point{R: p.R - 1, C: p.C})+dp.At(grid.Point{R: p.R, C: p.C - 1}))
 	}
-	return dp[m-1][n-1]
+	return dp.At(grid.Point{R: m - 1, C: n - 1})
 }
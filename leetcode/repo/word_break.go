@@ -1,5 +1,11 @@
 package repo
 
+import (
+	"fmt"
+
+	"leetcode/wordseg"
+)
+
 func wordBreak(s string, wordDict []string) bool {
 	// dp[i]表示s[i]截止可以被表示
 	dp := make([]bool, len(s)+1)
@@ -15,3 +21,23 @@ func wordBreak(s string, wordDict []string) bool {
 	}
 	return dp[len(s)]
 }
+
+// 字典树: 每个位置只沿字典树走一遍，不用遍历整个词典
+func wordBreakTrie(s string, wordDict []string) bool {
+	return wordseg.NewDictFromWords(wordDict).CanSegment(s)
+}
+
+// 140. 单词拆分 II
+func wordBreak2(s string, wordDict []string) []string {
+	var res []string
+	for seg := range wordseg.NewDictFromWords(wordDict).All(s) {
+		res = append(res, wordseg.Join(seg))
+	}
+	return res
+}
+
+func TestWordBreak() {
+	fmt.Println(wordBreak("leetcode", []string{"leet", "code"}), wordBreakTrie("leetcode", []string{"leet", "code"}))
+	fmt.Println(wordBreak("catsandog", []string{"cats", "dog", "sand", "and", "cat"}), wordBreakTrie("catsandog", []string{"cats", "dog", "sand", "and", "cat"}))
+	fmt.Printf("%q\n", wordBreak2("pineapplepenapple", []string{"apple", "pen", "applepen", "pine", "pineapple"}))
+}
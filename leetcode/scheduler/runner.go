@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Job 一个具体要执行的函数，Task为所属的任务类型
+type Job struct {
+	Task string
+	Run  func()
+}
+
+// Runner 按照Plan的结果真正执行任务
+// 每个时间片内各worker在独立的goroutine中并发执行，时间片之间同步等待，
+// 因此同类任务两次执行之间至少间隔 Cooldown 个时间片
+type Runner struct {
+	Workers         int
+	Tick            time.Duration  // 每个时间片的最短时长，空闲时间片也会等待
+	DefaultCooldown int            // 未在Cooldown中指定的任务使用的冷却时间
+	Cooldown        map[string]int // 按任务类型指定冷却时间
+	Priority        map[string]int // 按任务类型指定优先级
+}
+
+// Run 执行所有job并返回实际的调度结果，同类job按提交顺序执行
+func (r *Runner) Run(jobs []Job) (Schedule, error) {
+	queues := make(map[string][]func())
+	var tasks []Task
+	for _, j := range jobs {
+		if j.Task == Idle {
+			return Schedule{}, fmt.Errorf("task name %q is reserved", Idle)
+		}
+		if _, ok := queues[j.Task]; !ok {
+			cooldown, ok := r.Cooldown[j.Task]
+			if !ok {
+				cooldown = r.DefaultCooldown
+			}
+			tasks = append(tasks, Task{Name: j.Task, Cooldown: cooldown, Priority: r.Priority[j.Task]})
+		}
+		queues[j.Task] = append(queues[j.Task], j.Run)
+	}
+	for i := range tasks {
+		tasks[i].Count = len(queues[tasks[i].Name])
+	}
+
+	s := Plan(tasks, r.Workers)
+	for t := 0; t < s.Len(); t++ {
+		start := time.Now()
+		var wg sync.WaitGroup
+		for w := range s.Slots {
+			name := s.Slots[w][t]
+			if name == Idle {
+				continue
+			}
+			run := queues[name][0]
+			queues[name] = queues[name][1:]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				run()
+			}()
+		}
+		wg.Wait()
+		if d := r.Tick - time.Since(start); d > 0 {
+			time.Sleep(d)
+		}
+	}
+	return s, Validate(s, tasks)
+}
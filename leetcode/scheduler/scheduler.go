@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Idle 表示该时间片空闲
+const Idle = "idle"
+
+// Task 一类任务
+type Task struct {
+	Name     string
+	Count    int // 需要执行的次数
+	Cooldown int // 同类任务两次执行之间至少间隔的时间片
+	Priority int // 越大越优先
+}
+
+// Schedule Slots[w][t]为第w个worker在时间t执行的任务
+type Schedule struct {
+	Slots [][]string
+}
+
+// Len 总时长
+func (s Schedule) Len() int {
+	if len(s.Slots) == 0 {
+		return 0
+	}
+	return len(s.Slots[0])
+}
+
+func (s Schedule) String() string {
+	var sb strings.Builder
+	for w, line := range s.Slots {
+		fmt.Fprintf(&sb, "worker %d: %s\n", w, strings.Join(line, " -> "))
+	}
+	return sb.String()
+}
+
+// FromBytes 将leastInterval的输入转换为任务列表，所有任务冷却时间相同
+func FromBytes(tasks []byte, n int) []Task {
+	counter := make(map[byte]int)
+	for _, t := range tasks {
+		counter[t]++
+	}
+	res := make([]Task, 0, len(counter))
+	for b, c := range counter {
+		res = append(res, Task{Name: string(b), Count: c, Cooldown: n})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// Plan 贪心地生成执行顺序
+// 每个时间片在已冷却的任务中依次选取: 优先级高的，剩余关键路径 (剩余次数-1)*(冷却+1) 长的，名字小的
+// 所有任务优先级相同、冷却相同且只有一个worker时，结果长度与leastInterval的公式一致
+func Plan(tasks []Task, workers int) Schedule {
+	if workers < 1 {
+		workers = 1
+	}
+	remain := make([]int, len(tasks))
+	nextAvail := make([]int, len(tasks))
+	total := 0
+	for i, t := range tasks {
+		remain[i] = t.Count
+		total += t.Count
+	}
+	s := Schedule{Slots: make([][]string, workers)}
+	candidates := make([]int, 0, len(tasks))
+	for t := 0; total > 0; t++ {
+		candidates = candidates[:0]
+		for i := range tasks {
+			if remain[i] > 0 && nextAvail[i] <= t {
+				candidates = append(candidates, i)
+			}
+		}
+		sort.Slice(candidates, func(a, b int) bool {
+			x, y := candidates[a], candidates[b]
+			if tasks[x].Priority != tasks[y].Priority {
+				return tasks[x].Priority > tasks[y].Priority
+			}
+			kx := (remain[x] - 1) * (tasks[x].Cooldown + 1)
+			ky := (remain[y] - 1) * (tasks[y].Cooldown + 1)
+			if kx != ky {
+				return kx > ky
+			}
+			return tasks[x].Name < tasks[y].Name
+		})
+		for w := 0; w < workers; w++ {
+			if w >= len(candidates) {
+				s.Slots[w] = append(s.Slots[w], Idle)
+				continue
+			}
+			i := candidates[w]
+			s.Slots[w] = append(s.Slots[w], tasks[i].Name)
+			remain[i]--
+			total--
+			nextAvail[i] = t + tasks[i].Cooldown + 1
+		}
+	}
+	return s
+}
+
+// Validate 检查调度结果是否执行了所有任务且满足冷却约束
+func Validate(s Schedule, tasks []Task) error {
+	byName := make(map[string]Task, len(tasks))
+	for _, t := range tasks {
+		byName[t.Name] = t
+	}
+	times := make(map[string][]int)
+	for w, line := range s.Slots {
+		if len(line) != s.Len() {
+			return fmt.Errorf("worker %d has %d slots, want %d", w, len(line), s.Len())
+		}
+		for t, name := range line {
+			if name == Idle {
+				continue
+			}
+			if _, ok := byName[name]; !ok {
+				return fmt.Errorf("unknown task %q at worker %d time %d", name, w, t)
+			}
+			times[name] = append(times[name], t)
+		}
+	}
+	for name, task := range byName {
+		ts := times[name]
+		if len(ts) != task.Count {
+			return fmt.Errorf("task %q executed %d times, want %d", name, len(ts), task.Count)
+		}
+		sort.Ints(ts)
+		for i := 1; i < len(ts); i++ {
+			if ts[i]-ts[i-1] <= task.Cooldown {
+				return fmt.Errorf("task %q at time %d and %d violates cooldown %d", name, ts[i-1], ts[i], task.Cooldown)
+			}
+		}
+	}
+	return nil
+}
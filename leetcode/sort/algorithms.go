@@ -0,0 +1,145 @@
+package sort
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Algorithm 一个基于比较的排序算法
+type Algorithm[T any] struct {
+	Name   string
+	Stable bool // 相等元素排序后是否保持原有的相对顺序
+	Sort   func(s []T, cmp func(a, b T) int)
+}
+
+// Algorithms 包内所有基于比较的排序算法
+// 计数排序和基数排序不基于比较，单独提供: CountingSort、RadixSort、RadixSortStrings，三者均稳定
+func Algorithms[T any]() []Algorithm[T] {
+	return []Algorithm[T]{
+		{Name: "bubble", Stable: true, Sort: BubbleSortFunc[T]},
+		{Name: "insertion", Stable: true, Sort: InsertionSortFunc[T]},
+		{Name: "merge", Stable: true, Sort: MergeSortFunc[T]},
+		{Name: "quick", Stable: false, Sort: QuickSortFunc[T]},
+		{Name: "heap", Stable: false, Sort: HeapSortFunc[T]},
+		{Name: "shell", Stable: false, Sort: ShellSortFunc[T]},
+		{Name: "intro", Stable: false, Sort: IntroSortFunc[T]},
+	}
+}
+
+// randomInts 随机、有序、逆序、全相等等几类输入
+func randomInts(r *rand.Rand, n, limit int) [][]int {
+	random := make([]int, n)
+	for i := range random {
+		random[i] = r.Intn(limit) - limit/2
+	}
+	sorted := slices.Clone(random)
+	slices.Sort(sorted)
+	reversed := slices.Clone(sorted)
+	slices.Reverse(reversed)
+	equal := make([]int, n)
+	return [][]int{random, sorted, reversed, equal}
+}
+
+func TestSorts() {
+	r := rand.New(rand.NewSource(8))
+	bad := map[string]int{}
+	type record struct {
+		key, id int
+	}
+	for round := 0; round < 100; round++ {
+		n := r.Intn(300)
+		for _, input := range randomInts(r, n, 1+r.Intn(2*n+1)) {
+			want := slices.Clone(input)
+			slices.Sort(want)
+			for _, alg := range Algorithms[int]() {
+				got := slices.Clone(input)
+				alg.Sort(got, cmp.Compare[int])
+				if !slices.Equal(got, want) {
+					bad[alg.Name]++
+				}
+			}
+			for name, f := range map[string]func([]int){
+				"bubble": BubbleSort[int], "insertion": InsertionSort[int], "merge": MergeSort[int],
+				"quick": QuickSort[int], "heap": HeapSort[int], "shell": ShellSort[int], "intro": IntroSort[int],
+				"counting": CountingSort[int], "radix": RadixSort[int],
+			} {
+				got := slices.Clone(input)
+				f(got)
+				if !slices.Equal(got, want) {
+					bad[name]++
+				}
+			}
+
+			// 稳定性: 按key排序后，相同key的id应保持递增
+			records := make([]record, len(input))
+			for i, v := range input {
+				records[i] = record{key: v % 5, id: i}
+			}
+			byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+			stable := slices.Clone(records)
+			slices.SortStableFunc(stable, byKey)
+			for _, alg := range Algorithms[record]() {
+				got := slices.Clone(records)
+				alg.Sort(got, byKey)
+				if alg.Stable && !slices.Equal(got, stable) {
+					bad[alg.Name+"-stable"]++
+				}
+			}
+		}
+
+		// 其他整数类型与极端值
+		i8 := make([]int8, n)
+		u16 := make([]uint16, n)
+		i64 := make([]int64, n)
+		for i := 0; i < n; i++ {
+			i8[i] = int8(r.Intn(256) - 128)
+			u16[i] = uint16(r.Intn(1 << 16))
+			i64[i] = r.Int63() - r.Int63()
+		}
+		if n > 2 {
+			i64[0], i64[1] = math.MinInt64, math.MaxInt64
+		}
+		for _, f := range []func([]int8){CountingSort[int8], RadixSort[int8]} {
+			got := slices.Clone(i8)
+			f(got)
+			if !slices.IsSorted(got) {
+				bad["int8"]++
+			}
+		}
+		for _, f := range []func([]uint16){CountingSort[uint16], RadixSort[uint16]} {
+			got := slices.Clone(u16)
+			f(got)
+			if !slices.IsSorted(got) {
+				bad["uint16"]++
+			}
+		}
+		for _, f := range []func([]int64){CountingSort[int64], RadixSort[int64]} {
+			got := slices.Clone(i64)
+			f(got)
+			if !slices.IsSorted(got) {
+				bad["int64"]++
+			}
+		}
+
+		// 字符串
+		strs := make([]string, n)
+		for i := range strs {
+			var sb strings.Builder
+			for k := r.Intn(6); k > 0; k-- {
+				sb.WriteByte(byte('a' + r.Intn(3)))
+			}
+			strs[i] = sb.String()
+		}
+		want := slices.Clone(strs)
+		slices.Sort(want)
+		RadixSortStrings(strs)
+		if !slices.Equal(strs, want) {
+			bad["radix-strings"]++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+}
@@ -1,15 +1,20 @@
 package sort
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+)
 
 // 冒泡排序:稳定排序
 // 每一轮循环将最大的值不断交换到最后
-func bubbleSort(nums []int) {
+// t不为nil时记录每次比较和交换
+func bubbleSort(nums []int, t *Tracer) {
 	for i := len(nums) - 1; i >= 0; i-- {
 		for j := 0; j < i; j++ {
+			t.compare(j, j+1)
 			if nums[j] > nums[j+1] {
-
 				nums[j], nums[j+1] = nums[j+1], nums[j]
+				t.swap(j, j+1)
 			}
 		}
 	}
@@ -23,12 +28,27 @@ func TestBubbleSort() {
 
 // 剪枝优化
 func bubbleSort1(nums []int) {
-	for i := 0; i < len(nums)-1; i++ {
+	BubbleSort(nums)
+}
+
+// BubbleSort 泛型冒泡排序，稳定
+func BubbleSort[T cmp.Ordered](s []T) {
+	BubbleSortFunc(s, cmp.Compare[T])
+}
+
+// BubbleSortFunc 某一轮没有发生交换时说明已经有序，提前结束
+func BubbleSortFunc[T any](s []T, cmp func(a, b T) int) {
+	bubbleSortFunc(s, cmp, nil)
+}
+
+func bubbleSortFunc[T any](s []T, cmp func(a, b T) int, t *Tracer) {
+	for i := 0; i < len(s)-1; i++ {
 		swapped := false
-		for j := 0; j < len(nums)-i-1; j++ {
-			if nums[j] > nums[j+1] {
+		for j := 0; j < len(s)-i-1; j++ {
+			compareAt(t, &s[j], &s[j+1])
+			if cmp(s[j], s[j+1]) > 0 {
 				swapped = true
-				nums[j], nums[j+1] = nums[j+1], nums[j]
+				swapAt(t, &s[j], &s[j+1])
 			}
 		}
 		if !swapped {
@@ -0,0 +1,40 @@
+package sort
+
+import "leetcode/constraints"
+
+// countingLimit 值域超过该大小时计数排序改用基数排序
+const countingLimit = 1 << 20
+
+// CountingSort 计数排序，O(n+k)，k为值域大小
+// 值域超过 max(countingLimit, 4n) 时退化为RadixSort，避免申请过大的计数数组
+func CountingSort[T constraints.Integer](s []T) {
+	countingSort(s, nil)
+}
+
+// countingSort t不为nil时记录每次写入，计数排序没有比较
+func countingSort[T constraints.Integer](s []T, t *Tracer) {
+	if len(s) < 2 {
+		return
+	}
+	lo, hi := s[0], s[0]
+	for _, v := range s {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	// 按无符号数做减法，int64的极端值也不会溢出
+	span := uint64(hi) - uint64(lo)
+	if span >= uint64(max(countingLimit, 4*len(s))) {
+		radixSort(s, t)
+		return
+	}
+	count := make([]int, span+1)
+	for _, v := range s {
+		count[uint64(v)-uint64(lo)]++
+	}
+	k := 0
+	for i, c := range count {
+		for ; c > 0; c-- {
+			writeAt(t, &s[k], lo+T(i)) // 小整数类型可能回绕，结果仍然正确
+			k++
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package sort
+
+import "cmp"
+
+// HeapSort 堆排序，不稳定，O(nlogn)且不需要额外空间
+func HeapSort[T cmp.Ordered](s []T) {
+	HeapSortFunc(s, cmp.Compare[T])
+}
+
+func HeapSortFunc[T any](s []T, cmp func(a, b T) int) {
+	heapSortFunc(s, cmp, nil)
+}
+
+func heapSortFunc[T any](s []T, cmp func(a, b T) int, t *Tracer) {
+	// 建大根堆，再不断把堆顶交换到末尾
+	for i := len(s)/2 - 1; i >= 0; i-- {
+		siftDown(s, i, len(s), cmp, t)
+	}
+	for end := len(s) - 1; end > 0; end-- {
+		swapAt(t, &s[0], &s[end])
+		siftDown(s, 0, end, cmp, t)
+	}
+}
+
+func siftDown[T any](s []T, i, n int, cmp func(a, b T) int, t *Tracer) {
+	for {
+		largest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n {
+			compareAt(t, &s[left], &s[largest])
+			if cmp(s[left], s[largest]) > 0 {
+				largest = left
+			}
+		}
+		if right < n {
+			compareAt(t, &s[right], &s[largest])
+			if cmp(s[right], s[largest]) > 0 {
+				largest = right
+			}
+		}
+		if largest == i {
+			return
+		}
+		swapAt(t, &s[i], &s[largest])
+		i = largest
+	}
+}
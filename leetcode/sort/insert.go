@@ -1,17 +1,51 @@
 package sort
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+)
 
-func insertSort(nums []int) {
+// t不为nil时记录每次比较和交换
+func insertSort(nums []int, t *Tracer) {
 	for i := 1; i < len(nums); i++ {
-		for j := i - 1; j >= 0 && nums[j+1] < nums[j]; j-- {
+		for j := i - 1; j >= 0; j-- {
+			t.compare(j+1, j)
+			if nums[j+1] >= nums[j] {
+				break
+			}
 			nums[j], nums[j+1] = nums[j+1], nums[j]
+			t.swap(j, j+1)
 		}
 	}
 }
 
 func TestInsertSort() {
 	nums := []int{5, 3, 4, 1, 2}
-	insertSort(nums)
+	insertSort(nums, nil)
 	fmt.Println(nums)
 }
+
+// InsertionSort 泛型插入排序，稳定，对基本有序的输入接近O(n)
+func InsertionSort[T cmp.Ordered](s []T) {
+	InsertionSortFunc(s, cmp.Compare[T])
+}
+
+func InsertionSortFunc[T any](s []T, cmp func(a, b T) int) {
+	insertionSortFunc(s, cmp, nil)
+}
+
+// insertionSortFunc 待插入的元素暂存在v中，与它的比较记为和-1比较
+func insertionSortFunc[T any](s []T, cmp func(a, b T) int, t *Tracer) {
+	for i := 1; i < len(s); i++ {
+		v := s[i]
+		j := i - 1
+		for ; j >= 0; j-- {
+			compareAt(t, &s[j], nil)
+			if cmp(s[j], v) <= 0 {
+				break
+			}
+			writeAt(t, &s[j+1], s[j])
+		}
+		writeAt(t, &s[j+1], v)
+	}
+}
@@ -0,0 +1,68 @@
+package sort
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// IntroSort 内省排序: 快速排序 + 递归过深时改用堆排序 + 小区间插入排序，不稳定，最坏O(nlogn)
+func IntroSort[T cmp.Ordered](s []T) {
+	IntroSortFunc(s, cmp.Compare[T])
+}
+
+func IntroSortFunc[T any](s []T, cmp func(a, b T) int) {
+	introSort(s, cmp, 2*bits.Len(uint(len(s))), nil)
+}
+
+func introSort[T any](s []T, cmp func(a, b T) int, depth int, t *Tracer) {
+	for len(s) > 16 {
+		if depth == 0 {
+			heapSortFunc(s, cmp, t)
+			return
+		}
+		depth--
+		p := hoarePartition(s, cmp, t)
+		// 递归处理较短的一侧，较长的一侧继续循环，栈深度为O(logn)
+		if p < len(s)-p {
+			introSort(s[:p], cmp, depth, t)
+			s = s[p+1:]
+		} else {
+			introSort(s[p+1:], cmp, depth, t)
+			s = s[:p]
+		}
+	}
+	insertionSortFunc(s, cmp, t)
+}
+
+// hoarePartition 以三数取中为基准划分，返回基准的最终位置
+// 与基准相等的元素会被交换到两侧，重复元素多时划分依然均衡
+func hoarePartition[T any](s []T, cmp func(a, b T) int, t *Tracer) int {
+	k := medianIndex(s, 0, len(s)/2, len(s)-1, cmp, t)
+	swapAt(t, &s[0], &s[k])
+	pivot := s[0]
+	i, j := 1, len(s)-1
+	for {
+		for i <= j {
+			compareAt(t, &s[i], nil)
+			if cmp(s[i], pivot) >= 0 {
+				break
+			}
+			i++
+		}
+		for i <= j {
+			compareAt(t, &s[j], nil)
+			if cmp(s[j], pivot) <= 0 {
+				break
+			}
+			j--
+		}
+		if i >= j {
+			break
+		}
+		swapAt(t, &s[i], &s[j])
+		i++
+		j--
+	}
+	swapAt(t, &s[0], &s[j])
+	return j
+}
@@ -0,0 +1,49 @@
+package sort
+
+import "cmp"
+
+// MergeSort 归并排序，稳定，额外空间O(n)
+func MergeSort[T cmp.Ordered](s []T) {
+	MergeSortFunc(s, cmp.Compare[T])
+}
+
+func MergeSortFunc[T any](s []T, cmp func(a, b T) int) {
+	buf := make([]T, len(s))
+	mergeSort(s, buf, cmp, nil)
+}
+
+// mergeSort t不为nil时记录比较和写入，复制到buf也计为写入
+func mergeSort[T any](s, buf []T, cmp func(a, b T) int, t *Tracer) {
+	// 小区间直接插入排序
+	if len(s) <= 12 {
+		insertionSortFunc(s, cmp, t)
+		return
+	}
+	mid := len(s) / 2
+	mergeSort(s[:mid], buf[:mid], cmp, t)
+	mergeSort(s[mid:], buf[mid:], cmp, t)
+	compareAt(t, &s[mid-1], &s[mid])
+	if cmp(s[mid-1], s[mid]) <= 0 {
+		return // 已经有序
+	}
+	copyAt(t, buf, s)
+	merge(s, buf[:mid], buf[mid:len(s)], cmp, t)
+}
+
+// merge 将有序的a和b合并到dst，相等时先取a中的元素以保证稳定
+func merge[T any](dst, a, b []T, cmp func(a, b T) int, t *Tracer) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		compareAt(t, &b[j], &a[i])
+		if cmp(b[j], a[i]) < 0 {
+			writeAt(t, &dst[k], b[j])
+			j++
+		} else {
+			writeAt(t, &dst[k], a[i])
+			i++
+		}
+		k++
+	}
+	k += copyAt(t, dst[k:], a[i:])
+	copyAt(t, dst[k:], b[j:])
+}
@@ -0,0 +1,261 @@
+package sort
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ParallelOptions 并行排序的参数，零值表示使用默认值
+type ParallelOptions struct {
+	Workers  int // 同时运行的goroutine上限，默认GOMAXPROCS
+	Cutoff   int // 长度小于Cutoff的子数组不再并行，默认8192
+	MaxDepth int // 最多向下分叉的层数，默认64，实际主要受Workers限制
+}
+
+// forker 用带缓冲的channel限制同时运行的goroutine数量
+// 拿不到名额时在当前goroutine中直接执行，不会阻塞，也不会死锁
+// 插桩运行时trace不为nil，各goroutine共用
+type forker struct {
+	sem      chan struct{}
+	cutoff   int
+	maxDepth int
+	trace    *Tracer
+}
+
+func newForker(opt ParallelOptions, t *Tracer) *forker {
+	workers := opt.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	cutoff := opt.Cutoff
+	if cutoff <= 0 {
+		cutoff = 8192
+	}
+	maxDepth := opt.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = 64
+	}
+	// 当前goroutine占用一个名额
+	return &forker{sem: make(chan struct{}, max(0, workers-1)), cutoff: cutoff, maxDepth: maxDepth, trace: t}
+}
+
+// both 并发执行f和g，条件不满足时顺序执行
+func (f *forker) both(n, depth int, a, b func()) {
+	if n >= f.cutoff && depth < f.maxDepth {
+		select {
+		case f.sem <- struct{}{}:
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-f.sem }()
+				a()
+			}()
+			b()
+			wg.Wait()
+			return
+		default:
+		}
+	}
+	a()
+	b()
+}
+
+// ParallelMergeSort 并行归并排序，稳定，两半并发排序后再并行合并
+func ParallelMergeSort[T cmp.Ordered](s []T, opt ParallelOptions) {
+	ParallelMergeSortFunc(s, cmp.Compare[T], opt)
+}
+
+func ParallelMergeSortFunc[T any](s []T, cmp func(a, b T) int, opt ParallelOptions) {
+	parallelMergeSortFunc(s, cmp, opt, nil)
+}
+
+func parallelMergeSortFunc[T any](s []T, cmp func(a, b T) int, opt ParallelOptions, t *Tracer) {
+	f := newForker(opt, t)
+	buf := make([]T, len(s))
+	parallelMergeSort(f, s, buf, cmp, 0)
+}
+
+func parallelMergeSort[T any](f *forker, s, buf []T, cmp func(a, b T) int, depth int) {
+	if len(s) < f.cutoff {
+		mergeSort(s, buf, cmp, f.trace)
+		return
+	}
+	mid := len(s) / 2
+	// 各自排好后复制到buf，再从buf合并回s
+	f.both(len(s), depth, func() {
+		parallelMergeSort(f, s[:mid], buf[:mid], cmp, depth+1)
+		copyAt(f.trace, buf[:mid], s[:mid])
+	}, func() {
+		parallelMergeSort(f, s[mid:], buf[mid:], cmp, depth+1)
+		copyAt(f.trace, buf[mid:], s[mid:])
+	})
+	parallelMerge(f, s, buf[:mid], buf[mid:], cmp, depth)
+}
+
+// parallelMerge 将有序的a(左)和b(右)合并到dst
+// 取较长一侧的中位数，在另一侧二分找到分割点，两部分并发合并；相等元素a在前，保持稳定
+func parallelMerge[T any](f *forker, dst, a, b []T, cmp func(a, b T) int, depth int) {
+	if len(a)+len(b) < f.cutoff {
+		merge(dst, a, b, cmp, f.trace)
+		return
+	}
+	var i, j int
+	if len(a) >= len(b) {
+		i = len(a) / 2
+		// b中严格小于a[i]的元素排在a[i]之前
+		j, _ = slices.BinarySearchFunc(b, a[i], func(x, target T) int {
+			compareAt[T](f.trace, nil, nil)
+			if cmp(x, target) < 0 {
+				return -1
+			}
+			return 1
+		})
+		writeAt(f.trace, &dst[i+j], a[i])
+		f.both(len(dst), depth, func() {
+			parallelMerge(f, dst[:i+j], a[:i], b[:j], cmp, depth+1)
+		}, func() {
+			parallelMerge(f, dst[i+j+1:], a[i+1:], b[j:], cmp, depth+1)
+		})
+	} else {
+		j = len(b) / 2
+		// a中小于等于b[j]的元素排在b[j]之前
+		i, _ = slices.BinarySearchFunc(a, b[j], func(x, target T) int {
+			compareAt[T](f.trace, nil, nil)
+			if cmp(x, target) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		writeAt(f.trace, &dst[i+j], b[j])
+		f.both(len(dst), depth, func() {
+			parallelMerge(f, dst[:i+j], a[:i], b[:j], cmp, depth+1)
+		}, func() {
+			parallelMerge(f, dst[i+j+1:], a[i:], b[j+1:], cmp, depth+1)
+		})
+	}
+}
+
+// ParallelQuickSort 并行快速排序，不稳定，划分后两侧并发排序
+func ParallelQuickSort[T cmp.Ordered](s []T, opt ParallelOptions) {
+	ParallelQuickSortFunc(s, cmp.Compare[T], opt)
+}
+
+func ParallelQuickSortFunc[T any](s []T, cmp func(a, b T) int, opt ParallelOptions) {
+	parallelQuickSortFunc(s, cmp, opt, nil)
+}
+
+func parallelQuickSortFunc[T any](s []T, cmp func(a, b T) int, opt ParallelOptions, t *Tracer) {
+	f := newForker(opt, t)
+	parallelQuickSort(f, s, cmp, 0, 2*bits.Len(uint(len(s))))
+}
+
+// parallelQuickSort 与introSort一样限制划分的层数，超过limit层说明基准一直很差，改用堆排序，最坏O(nlogn)
+func parallelQuickSort[T any](f *forker, s []T, cmp func(a, b T) int, depth, limit int) {
+	if len(s) < f.cutoff {
+		introSort(s, cmp, 2*bits.Len(uint(len(s))), f.trace)
+		return
+	}
+	if limit == 0 {
+		heapSortFunc(s, cmp, f.trace)
+		return
+	}
+	k := choosePivot(s, PivotNinther, cmp, f.trace)
+	swapAt(f.trace, &s[0], &s[k])
+	lt, gt := partition3(s, cmp, f.trace)
+	f.both(len(s), depth, func() {
+		parallelQuickSort(f, s[:lt], cmp, depth+1, limit-1)
+	}, func() {
+		parallelQuickSort(f, s[gt:], cmp, depth+1, limit-1)
+	})
+}
+
+// antiQuickSort McIlroy的对抗输入: 元素的值在第一次参与比较时才确定，
+// 总让未确定的元素成为较大的一方，使快速排序每次都选到很差的基准
+// 返回待排序的下标和比较函数，比较次数记在*count中
+func antiQuickSort(n int, count *int) ([]int, func(a, b int) int) {
+	gas := n
+	val := make([]int, n)
+	s := make([]int, n)
+	for i := range s {
+		s[i], val[i] = i, gas
+	}
+	solid, candidate := 0, 0
+	return s, func(x, y int) int {
+		*count++
+		if val[x] == gas && val[y] == gas {
+			if x == candidate {
+				val[x] = solid
+			} else {
+				val[y] = solid
+			}
+			solid++
+		}
+		if val[x] == gas {
+			candidate = x
+		} else if val[y] == gas {
+			candidate = y
+		}
+		return cmp.Compare(val[x], val[y])
+	}
+}
+
+// 不同GOMAXPROCS下与顺序quickSort、slices.Sort比较耗时
+func TestParallelSort() {
+	r := rand.New(rand.NewSource(10))
+	n := 2000000
+	input := make([]int, n)
+	for i := range input {
+		input[i] = r.Int()
+	}
+	want := slices.Clone(input)
+	slices.Sort(want)
+
+	// 稳定性
+	type record struct{ key, id int }
+	records := make([]record, 200000)
+	for i := range records {
+		records[i] = record{key: r.Intn(100), id: i}
+	}
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+	stable := slices.Clone(records)
+	slices.SortStableFunc(stable, byKey)
+	got := slices.Clone(records)
+	ParallelMergeSortFunc(got, byKey, ParallelOptions{Workers: 4, Cutoff: 1000})
+	fmt.Println("merge stable:", slices.Equal(got, stable))
+	ParallelQuickSortFunc(got, func(a, b record) int { return cmp.Compare(a.id, b.id) }, ParallelOptions{Workers: 4, Cutoff: 1000})
+	fmt.Println("quick sorted:", slices.Equal(got, records))
+
+	// 对抗输入下比较次数仍为O(nlogn)
+	for _, n := range []int{10000, 100000} {
+		count := 0
+		s, byVal := antiQuickSort(n, &count)
+		ParallelQuickSortFunc(s, byVal, ParallelOptions{Workers: 1, Cutoff: 64})
+		comparisons := count
+		fmt.Printf("adversarial n=%d comparisons=%d (n*log2(n)=%d) sorted=%v\n",
+			n, comparisons, n*bits.Len(uint(n)), slices.IsSortedFunc(s, byVal))
+	}
+
+	old := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(old)
+	fmt.Println("cpus:", runtime.NumCPU())
+	for _, procs := range []int{1, 2, 4, 8} {
+		runtime.GOMAXPROCS(procs)
+		run := func(name string, f func(s []int)) {
+			s := slices.Clone(input)
+			start := time.Now()
+			f(s)
+			fmt.Printf("GOMAXPROCS=%d %-14s %-14v sorted=%v\n", procs, name, time.Since(start), slices.Equal(s, want))
+		}
+		run("parallel-merge", func(s []int) { ParallelMergeSort(s, ParallelOptions{}) })
+		run("parallel-quick", func(s []int) { ParallelQuickSort(s, ParallelOptions{}) })
+		run("quickSort", func(s []int) { quickSort(s, 0, len(s)-1, nil) })
+		run("slices.Sort", slices.Sort[[]int])
+	}
+}
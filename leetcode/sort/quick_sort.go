@@ -1,30 +1,251 @@
 package sort
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
 
-func quickSort(nums []int, left, right int) {
-	if left >= right {
-		return
-	}
-	l, r := left, right
-	pivot := nums[l]
-	for l < r {
-		for l < r && nums[r] >= pivot {
-			r--
+// 三数取中作为基准，三路划分处理重复元素，只递归较短的一侧
+// t不为nil时记录每次比较和交换，与基准(已复制到pivot)的比较记为和-1比较
+func quickSort(nums []int, left, right int, t *Tracer) {
+	for left < right {
+		mid := left + (right-left)/2
+		t.compare(mid, left)
+		if nums[mid] < nums[left] {
+			nums[mid], nums[left] = nums[left], nums[mid]
+			t.swap(mid, left)
+		}
+		t.compare(right, left)
+		if nums[right] < nums[left] {
+			nums[right], nums[left] = nums[left], nums[right]
+			t.swap(right, left)
+		}
+		t.compare(right, mid)
+		if nums[right] < nums[mid] {
+			nums[right], nums[mid] = nums[mid], nums[right]
+			t.swap(right, mid)
 		}
-		nums[l] = nums[r]
-		for l < r && nums[l] <= pivot {
-			l++
+		pivot := nums[mid]
+		// 荷兰国旗划分: [left, lt) < pivot, [lt, i) == pivot, (gt, right] > pivot
+		lt, i, gt := left, left, right
+		for i <= gt {
+			t.compare(i, -1)
+			if nums[i] < pivot {
+				nums[lt], nums[i] = nums[i], nums[lt]
+				t.swap(lt, i)
+				lt++
+				i++
+			} else if t.compare(i, -1); nums[i] > pivot {
+				nums[i], nums[gt] = nums[gt], nums[i]
+				t.swap(i, gt)
+				gt--
+			} else {
+				i++
+			}
+		}
+		if lt-left < right-gt {
+			quickSort(nums, left, lt-1, t)
+			left = gt + 1
+		} else {
+			quickSort(nums, gt+1, right, t)
+			right = lt - 1
 		}
-		nums[r] = nums[l]
 	}
-	nums[l] = pivot
-	quickSort(nums, left, l-1)
-	quickSort(nums, l+1, right)
 }
 
 func TestQuickSort() {
 	nums := []int{9, 4, 6, 1, 3, 2, 8, 7, 6, 5}
-	quickSort(nums, 0, len(nums)-1)
+	quickSort(nums, 0, len(nums)-1, nil)
 	fmt.Println(nums)
 }
+
+// Pivot 快速排序选取基准的策略
+type Pivot int
+
+const (
+	PivotFirst         Pivot = iota // 第一个元素，有序输入退化为O(n^2)
+	PivotRandom                     // 随机元素
+	PivotMedianOfThree              // 首、中、尾三数取中
+	PivotNinther                    // 九数取中: 三组三数取中再取中，适合大数组
+)
+
+func (p Pivot) String() string {
+	switch p {
+	case PivotFirst:
+		return "first"
+	case PivotRandom:
+		return "random"
+	case PivotMedianOfThree:
+		return "median3"
+	case PivotNinther:
+		return "ninther"
+	}
+	return fmt.Sprintf("Pivot(%d)", int(p))
+}
+
+// QuickSort 泛型快速排序，不稳定
+func QuickSort[T cmp.Ordered](s []T) {
+	QuickSortFunc(s, cmp.Compare[T])
+}
+
+func QuickSortFunc[T any](s []T, cmp func(a, b T) int) {
+	QuickSortWithFunc(s, PivotNinther, cmp)
+}
+
+// QuickSortWith 指定基准策略的快速排序
+func QuickSortWith[T cmp.Ordered](s []T, p Pivot) {
+	QuickSortWithFunc(s, p, cmp.Compare[T])
+}
+
+func QuickSortWithFunc[T any](s []T, p Pivot, cmp func(a, b T) int) {
+	quickSortWithFunc(s, p, cmp, nil)
+}
+
+func quickSortWithFunc[T any](s []T, p Pivot, cmp func(a, b T) int, t *Tracer) {
+	for len(s) > 12 {
+		k := choosePivot(s, p, cmp, t)
+		swapAt(t, &s[0], &s[k])
+		lt, gt := partition3(s, cmp, t)
+		// 尾递归消除: 递归较短的一侧，较长的一侧继续循环，栈深度不超过O(logn)
+		if lt < len(s)-gt {
+			quickSortWithFunc(s[:lt], p, cmp, t)
+			s = s[gt:]
+		} else {
+			quickSortWithFunc(s[gt:], p, cmp, t)
+			s = s[:lt]
+		}
+	}
+	insertionSortFunc(s, cmp, t)
+}
+
+// medianIndex 返回s[a], s[b], s[c]中位数的下标
+func medianIndex[T any](s []T, a, b, c int, cmp func(a, b T) int, t *Tracer) int {
+	compareAt(t, &s[a], &s[b])
+	if cmp(s[a], s[b]) > 0 {
+		a, b = b, a
+	}
+	// s[a] <= s[b]
+	compareAt(t, &s[b], &s[c])
+	if cmp(s[b], s[c]) <= 0 {
+		return b
+	}
+	compareAt(t, &s[a], &s[c])
+	if cmp(s[a], s[c]) <= 0 {
+		return c
+	}
+	return a
+}
+
+func choosePivot[T any](s []T, p Pivot, cmp func(a, b T) int, t *Tracer) int {
+	n := len(s)
+	switch p {
+	case PivotRandom:
+		return rand.IntN(n)
+	case PivotMedianOfThree:
+		return medianIndex(s, 0, n/2, n-1, cmp, t)
+	case PivotNinther:
+		if n < 40 {
+			return medianIndex(s, 0, n/2, n-1, cmp, t)
+		}
+		d := n / 8
+		m1 := medianIndex(s, 0, d, 2*d, cmp, t)
+		m2 := medianIndex(s, n/2-d, n/2, n/2+d, cmp, t)
+		m3 := medianIndex(s, n-1-2*d, n-1-d, n-1, cmp, t)
+		return medianIndex(s, m1, m2, m3, cmp, t)
+	}
+	return 0
+}
+
+// partition3 以s[0]为基准的三路划分，与sortColors的思路相同
+// 返回后 s[:lt] < pivot, s[lt:gt] == pivot, s[gt:] > pivot
+func partition3[T any](s []T, cmp func(a, b T) int, t *Tracer) (int, int) {
+	pivot := s[0]
+	lt, i, gt := 0, 0, len(s)
+	for i < gt {
+		compareAt(t, &s[i], nil)
+		c := cmp(s[i], pivot)
+		if c < 0 {
+			swapAt(t, &s[lt], &s[i])
+			lt++
+			i++
+		} else if c > 0 {
+			gt--
+			swapAt(t, &s[i], &s[gt])
+		} else {
+			i++
+		}
+	}
+	return lt, gt
+}
+
+// 对抗性输入下各基准策略的耗时
+func TestQuickSortAdversarial() {
+	n := 20000
+	inputs := map[string]func() []int{
+		"sorted": func() []int {
+			s := make([]int, n)
+			for i := range s {
+				s[i] = i
+			}
+			return s
+		},
+		"reversed": func() []int {
+			s := make([]int, n)
+			for i := range s {
+				s[i] = n - i
+			}
+			return s
+		},
+		"equal": func() []int {
+			return make([]int, n)
+		},
+		"few-unique": func() []int {
+			s := make([]int, n)
+			for i := range s {
+				s[i] = rand.IntN(4)
+			}
+			return s
+		},
+		"organ-pipe": func() []int {
+			s := make([]int, n)
+			for i := range s {
+				s[i] = min(i, n-i)
+			}
+			return s
+		},
+		"random": func() []int {
+			s := make([]int, n)
+			for i := range s {
+				s[i] = rand.IntN(n)
+			}
+			return s
+		},
+	}
+	names := []string{"sorted", "reversed", "equal", "few-unique", "organ-pipe", "random"}
+	for _, name := range names {
+		input := inputs[name]()
+		want := slices.Clone(input)
+		slices.Sort(want)
+		fmt.Printf("%-10s", name)
+		for _, p := range []Pivot{PivotFirst, PivotRandom, PivotMedianOfThree, PivotNinther} {
+			s := slices.Clone(input)
+			start := time.Now()
+			QuickSortWith(s, p)
+			fmt.Printf(" %s=%-12v", p, time.Since(start))
+			if !slices.Equal(s, want) {
+				fmt.Print("(wrong)")
+			}
+		}
+		s := slices.Clone(input)
+		start := time.Now()
+		quickSort(s, 0, len(s)-1, nil)
+		fmt.Printf(" quickSort=%v", time.Since(start))
+		if !slices.Equal(s, want) {
+			fmt.Print("(wrong)")
+		}
+		fmt.Println()
+	}
+}
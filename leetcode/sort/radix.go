@@ -0,0 +1,98 @@
+package sort
+
+import (
+	"unsafe"
+
+	"leetcode/constraints"
+)
+
+// RadixSort LSD基数排序，每轮按一个字节分桶，稳定，O(n*字节数)
+// 有符号数翻转最高位，使负数排在正数之前
+func RadixSort[T constraints.Integer](s []T) {
+	radixSort(s, nil)
+}
+
+// radixSort t不为nil时记录每次写入，写入辅助数组也计数
+func radixSort[T constraints.Integer](s []T, t *Tracer) {
+	if len(s) < 2 {
+		return
+	}
+	var zero T
+	size := int(unsafe.Sizeof(zero))
+	signed := ^zero < 0
+	key := func(v T) uint64 {
+		k := uint64(v)
+		if signed {
+			k ^= 1 << (8*size - 1)
+		}
+		return k
+	}
+	buf := make([]T, len(s))
+	src, dst := s, buf
+	var count [256]int
+	for pass := 0; pass < size; pass++ {
+		shift := uint(8 * pass)
+		count = [256]int{}
+		for _, v := range src {
+			count[key(v)>>shift&0xff]++
+		}
+		// 所有元素这一字节相同时跳过
+		if count[key(src[0])>>shift&0xff] == len(src) {
+			continue
+		}
+		pos := 0
+		for b := range count {
+			count[b], pos = pos, pos+count[b]
+		}
+		for _, v := range src {
+			b := key(v) >> shift & 0xff
+			writeAt(t, &dst[count[b]], v)
+			count[b]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] {
+		copyAt(t, s, src)
+	}
+}
+
+// RadixSortStrings LSD基数排序字符串，按字节序(与字符串比较一致)，稳定
+// 较短的字符串在缺失的位置视为比任何字节都小
+func RadixSortStrings(s []string) {
+	if len(s) < 2 {
+		return
+	}
+	maxLen := 0
+	for _, v := range s {
+		maxLen = max(maxLen, len(v))
+	}
+	// 桶0表示该位置没有字符
+	bucket := func(v string, i int) int {
+		if i >= len(v) {
+			return 0
+		}
+		return int(v[i]) + 1
+	}
+	buf := make([]string, len(s))
+	src, dst := s, buf
+	var count [257]int
+	for i := maxLen - 1; i >= 0; i-- {
+		count = [257]int{}
+		for _, v := range src {
+			count[bucket(v, i)]++
+		}
+		pos := 0
+		for b := range count {
+			count[b], pos = pos, pos+count[b]
+		}
+		for _, v := range src {
+			b := bucket(v, i)
+			dst[count[b]] = v
+			count[b]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
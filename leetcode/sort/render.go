@@ -0,0 +1,162 @@
+package sort
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// highlight 当前步骤涉及的下标
+func highlight(s Step) map[int]bool {
+	h := map[int]bool{}
+	if s.I >= 0 {
+		h[s.I] = true
+	}
+	if s.J >= 0 {
+		h[s.J] = true
+	}
+	return h
+}
+
+// scale 将值线性映射到 [1, height]
+func scale(values []int, height int) []int {
+	res := make([]int, len(values))
+	if len(values) == 0 {
+		return res
+	}
+	lo, hi := slices.Min(values), slices.Max(values)
+	for i, v := range values {
+		if hi == lo {
+			res[i] = height
+		} else {
+			res[i] = 1 + (v-lo)*(height-1)/(hi-lo)
+		}
+	}
+	return res
+}
+
+// RenderTerminal 在终端中以柱状图动画回放，最多maxFrames帧，当前操作的柱子标红
+func RenderTerminal(w io.Writer, r *Recording, height, maxFrames int, delay time.Duration) {
+	every := max(1, (len(r.Steps)+maxFrames-1)/max(1, maxFrames))
+	for f := range r.Frames(every) {
+		var sb strings.Builder
+		sb.WriteString("\x1b[H\x1b[2J") // 光标归位并清屏
+		bars := scale(f.Values, height)
+		h := highlight(f.Step)
+		for level := height; level >= 1; level-- {
+			for i, b := range bars {
+				switch {
+				case b < level:
+					sb.WriteByte(' ')
+				case h[i]:
+					sb.WriteString("\x1b[31m█\x1b[0m")
+				default:
+					sb.WriteString("█")
+				}
+			}
+			sb.WriteByte('\n')
+		}
+		fmt.Fprintf(&sb, "%s step %d/%d %s(%d,%d) comparisons=%d swaps=%d writes=%d\n",
+			r.Algorithm, f.Index, len(r.Steps), f.Step.Op, f.Step.I, f.Step.J,
+			f.Stats.Comparisons, f.Stats.Swaps, f.Stats.Writes)
+		io.WriteString(w, sb.String())
+		time.Sleep(delay)
+	}
+}
+
+// SVGFrame 将一帧渲染为SVG柱状图
+func SVGFrame(r *Recording, f Frame, width, height int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`+"\n", width, height+20)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height+20)
+	bars := scale(f.Values, height)
+	h := highlight(f.Step)
+	barWidth := float64(width) / float64(max(1, len(bars)))
+	for i, b := range bars {
+		color := "steelblue"
+		if h[i] {
+			color = "crimson"
+		}
+		fmt.Fprintf(&sb, `<rect x="%.2f" y="%d" width="%.2f" height="%d" fill="%s"/>`+"\n",
+			float64(i)*barWidth, height-b, barWidth*0.9, b, color)
+	}
+	fmt.Fprintf(&sb, `<text x="2" y="%d" font-size="12" font-family="monospace">%s step %d/%d comparisons=%d swaps=%d writes=%d</text>`+"\n",
+		height+15, r.Algorithm, f.Index, len(r.Steps), f.Stats.Comparisons, f.Stats.Swaps, f.Stats.Writes)
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// WriteSVGFrames 将回放写成 dir/<算法>_00001.svg 这样的帧序列，返回写入的文件
+func WriteSVGFrames(dir string, r *Recording, maxFrames int) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	every := max(1, (len(r.Steps)+maxFrames-1)/max(1, maxFrames))
+	var files []string
+	for f := range r.Frames(every) {
+		name := filepath.Join(dir, fmt.Sprintf("%s_%05d.svg", r.Algorithm, len(files)+1))
+		if err := os.WriteFile(name, []byte(SVGFrame(r, f, 600, 300)), 0o644); err != nil {
+			return files, err
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+// inversions 逆序对个数，等于插入排序和冒泡排序的交换次数
+func inversions(nums []int) int {
+	n := 0
+	for i := range nums {
+		for j := i + 1; j < len(nums); j++ {
+			if nums[i] > nums[j] {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// 终端动画和SVG帧默认不输出，设置 SORT_RENDER_DIR=目录 时在终端回放并把SVG帧写到该目录
+func TestInstrumented() {
+	r := rand.New(rand.NewSource(9))
+	nums := make([]int, 200)
+	for i := range nums {
+		nums[i] = r.Intn(1000)
+	}
+	want := slices.Clone(nums)
+	slices.Sort(want)
+	fmt.Printf("%-14s %12s %8s %8s\n", "algorithm", "comparisons", "swaps", "writes")
+	for _, name := range InstrumentedNames() {
+		rec, _ := Instrument(name, nums, true)
+		// 回放的最后一帧应与排序结果一致，不记录步骤时计数相同
+		last := rec.Initial
+		for f := range rec.Frames(1) {
+			last = f.Values
+		}
+		quiet, _ := Instrument(name, nums, false)
+		ok := slices.Equal(rec.Final, want) && slices.Equal(last, want) && quiet.Stats == rec.Stats
+		fmt.Printf("%-14s %12d %8d %8d %v\n", name, rec.Comparisons, rec.Swaps, rec.Writes, ok)
+	}
+	inv := inversions(nums)
+	for _, name := range []string{"bubbleSort", "insertSort", "bubble"} {
+		rec, _ := Instrument(name, nums, false)
+		fmt.Printf("%s swaps=%d inversions=%d\n", name, rec.Swaps, inv)
+	}
+
+	rec, _ := Instrument("insertion", []int{5, 2, 4, 6, 1, 3}, true)
+	var last Frame
+	for f := range rec.Frames(1) {
+		last = f
+	}
+	fmt.Println("svg bars:", strings.Count(SVGFrame(rec, last, 600, 300), "<rect")-1)
+	if dir := os.Getenv("SORT_RENDER_DIR"); dir != "" {
+		RenderTerminal(os.Stdout, rec, 6, 3, 0)
+		files, err := WriteSVGFrames(dir, rec, 10)
+		fmt.Println(len(files), err)
+	}
+}
@@ -0,0 +1,39 @@
+package sort
+
+import "cmp"
+
+// ciura 经验上较优的希尔排序步长序列，更大的步长按2.25倍扩展
+var ciura = []int{1, 4, 10, 23, 57, 132, 301, 701}
+
+// ShellSort 希尔排序，不稳定
+func ShellSort[T cmp.Ordered](s []T) {
+	ShellSortFunc(s, cmp.Compare[T])
+}
+
+func ShellSortFunc[T any](s []T, cmp func(a, b T) int) {
+	shellSortFunc(s, cmp, nil)
+}
+
+func shellSortFunc[T any](s []T, cmp func(a, b T) int, t *Tracer) {
+	gaps := append([]int(nil), ciura...)
+	for g := gaps[len(gaps)-1]; g < len(s)/2; {
+		g = g * 9 / 4
+		gaps = append(gaps, g)
+	}
+	for k := len(gaps) - 1; k >= 0; k-- {
+		gap := gaps[k]
+		// 步长为gap的插入排序
+		for i := gap; i < len(s); i++ {
+			v := s[i]
+			j := i
+			for ; j >= gap; j -= gap {
+				compareAt(t, &s[j-gap], nil)
+				if cmp(s[j-gap], v) <= 0 {
+					break
+				}
+				writeAt(t, &s[j], s[j-gap])
+			}
+			writeAt(t, &s[j], v)
+		}
+	}
+}
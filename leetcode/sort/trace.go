@@ -0,0 +1,256 @@
+package sort
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"sync"
+	"unsafe"
+)
+
+// Op 被记录的操作类型
+type Op int
+
+const (
+	OpCompare Op = iota // 比较 I 和 J 上的元素，-1表示该元素不在数组中(暂存在临时变量或辅助数组里)
+	OpSwap              // 交换 I 和 J
+	OpWrite             // 将 Value 写入 I
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpCompare:
+		return "compare"
+	case OpSwap:
+		return "swap"
+	case OpWrite:
+		return "write"
+	}
+	return fmt.Sprintf("Op(%d)", int(o))
+}
+
+// Step 排序过程中的一步
+type Step struct {
+	Op    Op
+	I, J  int
+	Value int
+}
+
+// Stats 比较、交换和写入的次数，交换不计入写入，写入辅助数组也计数
+type Stats struct {
+	Comparisons int
+	Swaps       int
+	Writes      int
+}
+
+func (s *Stats) add(op Op) {
+	switch op {
+	case OpCompare:
+		s.Comparisons++
+	case OpSwap:
+		s.Swaps++
+	case OpWrite:
+		s.Writes++
+	}
+}
+
+// Recording 一次排序的完整记录
+type Recording struct {
+	Algorithm string
+	Initial   []int
+	Final     []int
+	Steps     []Step // 只有开启记录时才有
+	Stats
+}
+
+// Tracer 排序算法中的插桩点: 算法在比较、交换、写入数组的地方直接调用，nil表示不记录
+// 下标为-1表示该元素不在被排序的数组中，例如暂存在临时变量或辅助数组里
+type Tracer struct {
+	data   []int // 被排序的数组，用于把元素地址换算成下标
+	rec    *Recording
+	record bool
+	mu     sync.Mutex // 并行排序的多个goroutine同时记录
+}
+
+func (t *Tracer) log(s Step) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rec.Stats.add(s.Op)
+	if t.record {
+		t.rec.Steps = append(t.rec.Steps, s)
+	}
+}
+
+// compare 比较i和j上的元素
+func (t *Tracer) compare(i, j int) {
+	if t != nil {
+		t.log(Step{Op: OpCompare, I: i, J: j})
+	}
+}
+
+// swap 交换了i和j上的元素
+func (t *Tracer) swap(i, j int) {
+	if t != nil {
+		t.log(Step{Op: OpSwap, I: i, J: j})
+	}
+}
+
+// write 将v写入了i
+func (t *Tracer) write(i, v int) {
+	if t != nil {
+		t.log(Step{Op: OpWrite, I: i, J: -1, Value: v})
+	}
+}
+
+// index 泛型算法在子切片上递归，由元素的地址换算出在原数组中的下标
+func (t *Tracer) index(p unsafe.Pointer) int {
+	base := uintptr(unsafe.Pointer(unsafe.SliceData(t.data)))
+	off := uintptr(p) - base
+	if uintptr(p) < base || off >= uintptr(len(t.data))*unsafe.Sizeof(0) {
+		return -1
+	}
+	return int(off / unsafe.Sizeof(0))
+}
+
+// 下面三个方法不内联，使插桩函数足够小而被内联，t为nil时只多一次判断
+
+//go:noinline
+func (t *Tracer) compared(a, b unsafe.Pointer) {
+	t.compare(t.index(a), t.index(b))
+}
+
+//go:noinline
+func (t *Tracer) swapped(a, b unsafe.Pointer) {
+	t.swap(t.index(a), t.index(b))
+}
+
+// written 只有对[]int排序时才会插桩，p指向刚写入的int
+//
+//go:noinline
+func (t *Tracer) written(p unsafe.Pointer) {
+	t.write(t.index(p), *(*int)(p))
+}
+
+// compareAt 记录*a与*b的一次比较，比较由调用方紧接着完成
+// 与cmp的调用写在一起时超出内联预算，因此单独调用
+func compareAt[T any](t *Tracer, a, b *T) {
+	if t != nil {
+		t.compared(unsafe.Pointer(a), unsafe.Pointer(b))
+	}
+}
+
+// swapAt 交换*a和*b并记录
+func swapAt[T any](t *Tracer, a, b *T) {
+	*a, *b = *b, *a
+	if t != nil {
+		t.swapped(unsafe.Pointer(a), unsafe.Pointer(b))
+	}
+}
+
+// writeAt 将v写入*p并记录
+func writeAt[T any](t *Tracer, p *T, v T) {
+	*p = v
+	if t != nil {
+		t.written(unsafe.Pointer(p))
+	}
+}
+
+// copyAt 与copy相同，每个元素记一次写入
+func copyAt[T any](t *Tracer, dst, src []T) int {
+	if t == nil {
+		return copy(dst, src)
+	}
+	n := min(len(dst), len(src))
+	for i := range n {
+		writeAt(t, &dst[i], src[i])
+	}
+	return n
+}
+
+// instrumented 可以插桩运行的算法，包括原始的[]int版本和并行排序
+func instrumented() map[string]func(s []int, t *Tracer) {
+	c := cmp.Compare[int]
+	parallel := ParallelOptions{Workers: 4, Cutoff: 16}
+	return map[string]func(s []int, t *Tracer){
+		"bubble":    func(s []int, t *Tracer) { bubbleSortFunc(s, c, t) },
+		"insertion": func(s []int, t *Tracer) { insertionSortFunc(s, c, t) },
+		"merge":     func(s []int, t *Tracer) { mergeSort(s, make([]int, len(s)), c, t) },
+		"quick":     func(s []int, t *Tracer) { quickSortWithFunc(s, PivotNinther, c, t) },
+		"heap":      func(s []int, t *Tracer) { heapSortFunc(s, c, t) },
+		"shell":     func(s []int, t *Tracer) { shellSortFunc(s, c, t) },
+		"intro":     func(s []int, t *Tracer) { introSort(s, c, 2*bits.Len(uint(len(s))), t) },
+		"counting":  countingSort[int],
+		"radix":     radixSort[int],
+
+		"bubbleSort": bubbleSort,
+		"insertSort": insertSort,
+		"quickSort":  func(s []int, t *Tracer) { quickSort(s, 0, len(s)-1, t) },
+
+		"parallel-merge": func(s []int, t *Tracer) { parallelMergeSortFunc(s, c, parallel, t) },
+		"parallel-quick": func(s []int, t *Tracer) { parallelQuickSortFunc(s, c, parallel, t) },
+	}
+}
+
+// InstrumentedNames 支持插桩运行的算法名
+func InstrumentedNames() []string {
+	m := instrumented()
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Instrument 以插桩模式运行指定算法，record为true时记录每一步
+func Instrument(name string, nums []int, record bool) (*Recording, error) {
+	run, ok := instrumented()[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm %q", name)
+	}
+	rec := &Recording{Algorithm: name, Initial: slices.Clone(nums)}
+	t := &Tracer{data: slices.Clone(nums), rec: rec, record: record}
+	run(t.data, t)
+	rec.Final = t.data
+	return rec, nil
+}
+
+// Frame 回放到某一步时的状态
+type Frame struct {
+	Index  int   // 第几步，从1开始
+	Step   Step  // 刚执行完的操作
+	Values []int // 执行完该步后的数组，迭代过程中会被复用
+	Stats  Stats // 截止到该步的累计次数
+}
+
+// Frames 从初始状态回放所有步骤，每every步产出一帧，最后一步总会产出
+func (r *Recording) Frames(every int) iter.Seq[Frame] {
+	if every < 1 {
+		every = 1
+	}
+	return func(yield func(Frame) bool) {
+		values := slices.Clone(r.Initial)
+		var stats Stats
+		for i, s := range r.Steps {
+			stats.add(s.Op)
+			switch s.Op {
+			case OpSwap:
+				if s.I >= 0 && s.J >= 0 {
+					values[s.I], values[s.J] = values[s.J], values[s.I]
+				}
+			case OpWrite:
+				// 写入辅助数组只计数
+				if s.I >= 0 {
+					values[s.I] = s.Value
+				}
+			}
+			if (i+1)%every == 0 || i == len(r.Steps)-1 {
+				if !yield(Frame{Index: i + 1, Step: s, Values: values, Stats: stats}) {
+					return
+				}
+			}
+		}
+	}
+}
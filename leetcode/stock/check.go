@@ -0,0 +1,51 @@
+package stock
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// bruteForce 逐天枚举 买/卖/不动，返回满足限制的最大收益
+func bruteForce(prices []int, c Constraints[int]) int {
+	best := 0
+	var dfs func(day, trades, buyDay, lastSell, profit int)
+	dfs = func(day, trades, buyDay, lastSell, profit int) {
+		if day == len(prices) {
+			if buyDay < 0 {
+				best = max(best, profit)
+			}
+			return
+		}
+		dfs(day+1, trades, buyDay, lastSell, profit)
+		if buyDay >= 0 {
+			dfs(day+1, trades, -1, day, profit+prices[day]-prices[buyDay]-c.Fee)
+		} else if day > lastSell+c.Cooldown && (c.MaxTransactions <= 0 || trades < c.MaxTransactions) {
+			dfs(day+1, trades+1, day, lastSell, profit)
+		}
+	}
+	dfs(0, 0, -1, -1-c.Cooldown, 0)
+	return best
+}
+
+func TestOptimize() {
+	r := rand.New(rand.NewSource(48))
+	bad := 0
+	for round := 0; round < 2000; round++ {
+		prices := make([]int, r.Intn(11))
+		for i := range prices {
+			prices[i] = 1 + r.Intn(10)
+		}
+		c := Constraints[int]{MaxTransactions: r.Intn(4), Cooldown: r.Intn(3), Fee: r.Intn(3)}
+		res := Optimize(prices, c)
+		profit, err := Evaluate(prices, res.Trades, c)
+		if res.Profit != bruteForce(prices, c) || err != nil || profit != res.Profit {
+			bad++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+
+	prices := []float64{7.5, 1.25, 5, 3.5, 6.75, 4}
+	fmt.Printf("%+v\n", Optimize(prices, Constraints[float64]{}))
+	fmt.Printf("%+v\n", Optimize(prices, Constraints[float64]{MaxTransactions: 1}))
+	fmt.Printf("%+v\n", Optimize(prices, Constraints[float64]{Fee: 2}))
+}
@@ -0,0 +1,134 @@
+package stock
+
+import (
+	"errors"
+	"fmt"
+
+	"leetcode/constraints"
+)
+
+// Constraints 交易限制。只能先买后卖，同一时间最多持有一股，不允许卖空
+type Constraints[T constraints.Real] struct {
+	MaxTransactions int // 最多完成几次买卖，<=0表示不限
+	Cooldown        int // 卖出后需要等待的天数，1表示卖出后的第二天不能买入
+	Fee             T   // 每次完成买卖的手续费，在卖出时扣除
+}
+
+// Trade 一次买卖，Buy和Sell为价格数组的下标
+type Trade struct {
+	Buy, Sell int
+}
+
+// Result 最大收益和对应的交易
+type Result[T constraints.Real] struct {
+	Profit T
+	Trades []Trade
+}
+
+// Optimize 在限制下求最大收益，并回溯出具体的买卖日期
+// free[i][t]: 第i天结束时空仓、最多开过t次仓的最大收益
+// hold[i][t]: 第i天结束时持仓、最多开过t次仓的最大收益
+// 买入时从冷却期之前的free转移，卖出时扣手续费。O(n*k)时间和空间，k不限时为O(n)
+func Optimize[T constraints.Real](prices []T, c Constraints[T]) Result[T] {
+	n := len(prices)
+	if n < 2 {
+		return Result[T]{}
+	}
+	// 每次交易至少占两天，k >= n/2时等价于不限次数，只保留一层
+	limited := c.MaxTransactions > 0 && c.MaxTransactions < n/2
+	layers := 1
+	if limited {
+		layers = c.MaxTransactions + 1
+	}
+	prevLayer := func(t int) int {
+		if limited {
+			return t - 1
+		}
+		return t
+	}
+	free := make([][]T, n)
+	hold := make([][]T, n)
+	sold := make([][]bool, n)   // free[i][t]是否由第i天卖出得到
+	bought := make([][]bool, n) // hold[i][t]是否由第i天买入得到
+	// freeAt 冷却期之前的空仓收益，第0天之前为0
+	freeAt := func(i, t int) T {
+		if i < 0 {
+			return 0
+		}
+		return free[i][t]
+	}
+	first := 0
+	if limited {
+		first = 1 // 第0层不能开仓
+	}
+	for i := 0; i < n; i++ {
+		free[i] = make([]T, layers)
+		hold[i] = make([]T, layers)
+		sold[i] = make([]bool, layers)
+		bought[i] = make([]bool, layers)
+		for t := 0; t < layers; t++ {
+			if i > 0 {
+				free[i][t] = free[i-1][t]
+				// 严格大于才交易，收益相同时不做多余的买卖
+				if v := hold[i-1][t] + prices[i] - c.Fee; t >= first && v > free[i][t] {
+					free[i][t], sold[i][t] = v, true
+				}
+			}
+			if t < first {
+				continue
+			}
+			buy := freeAt(i-1-c.Cooldown, prevLayer(t)) - prices[i]
+			if i == 0 || buy > hold[i-1][t] {
+				hold[i][t], bought[i][t] = buy, true
+			} else {
+				hold[i][t] = hold[i-1][t]
+			}
+		}
+	}
+
+	res := Result[T]{Profit: free[n-1][layers-1]}
+	// 从最后一天的空仓状态回溯
+	i, t, holding := n-1, layers-1, false
+	sell := -1
+	for i >= 0 {
+		if !holding {
+			if sold[i][t] {
+				sell, holding = i, true
+			}
+			i--
+			continue
+		}
+		if bought[i][t] {
+			res.Trades = append(res.Trades, Trade{Buy: i, Sell: sell})
+			i -= 1 + c.Cooldown
+			t = prevLayer(t)
+			holding = false
+			continue
+		}
+		i--
+	}
+	for l, r := 0, len(res.Trades)-1; l < r; l, r = l+1, r-1 {
+		res.Trades[l], res.Trades[r] = res.Trades[r], res.Trades[l]
+	}
+	return res
+}
+
+// Evaluate 检查交易是否满足限制并计算收益
+func Evaluate[T constraints.Real](prices []T, trades []Trade, c Constraints[T]) (T, error) {
+	var profit T
+	if c.MaxTransactions > 0 && len(trades) > c.MaxTransactions {
+		return 0, fmt.Errorf("stock: %d trades exceed limit %d", len(trades), c.MaxTransactions)
+	}
+	lastSell := -1 - c.Cooldown
+	for _, tr := range trades {
+		if tr.Buy < 0 || tr.Sell >= len(prices) || tr.Buy >= tr.Sell {
+			return 0, fmt.Errorf("stock: invalid trade %v", tr)
+		}
+		if tr.Buy <= lastSell+c.Cooldown {
+			return 0, errors.New("stock: trades overlap or violate cooldown")
+		}
+		profit += prices[tr.Sell] - prices[tr.Buy] - c.Fee
+		lastSell = tr.Sell
+	}
+	return profit, nil
+}
@@ -0,0 +1,190 @@
+package structure
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+
+	"leetcode/constraints"
+)
+
+// Fenwick 树状数组，单点加、前缀和都是O(logn)，下标从0开始
+type Fenwick[T constraints.Number] struct {
+	tree []T // tree[i]存 (i - lowbit(i), i] 的和，下标从1开始
+}
+
+func NewFenwick[T constraints.Number](n int) *Fenwick[T] {
+	return &Fenwick[T]{tree: make([]T, n+1)}
+}
+
+// NewFenwickFrom 由数组O(n)建树
+func NewFenwickFrom[T constraints.Number](nums []T) *Fenwick[T] {
+	f := NewFenwick[T](len(nums))
+	for i, v := range nums {
+		f.tree[i+1] += v
+		if j := i + 1 + (i+1)&-(i+1); j < len(f.tree) {
+			f.tree[j] += f.tree[i+1]
+		}
+	}
+	return f
+}
+
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Add nums[i] += delta
+func (f *Fenwick[T]) Add(i int, delta T) {
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// PrefixSum nums[0:i]的和
+func (f *Fenwick[T]) PrefixSum(i int) T {
+	var sum T
+	for ; i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// RangeSum nums[l:r]的和
+func (f *Fenwick[T]) RangeSum(l, r int) T {
+	return f.PrefixSum(r) - f.PrefixSum(l)
+}
+
+func (f *Fenwick[T]) Get(i int) T {
+	return f.RangeSum(i, i+1)
+}
+
+// Set nums[i] = v
+func (f *Fenwick[T]) Set(i int, v T) {
+	f.Add(i, v-f.Get(i))
+}
+
+// FindKth 最小的i使得nums[0:i+1]的和 >= k，不存在返回Len()
+// 要求所有元素非负，用倍增在树上二分，O(logn)
+// 元素为0/1时就是第k个1的位置，例如找第k个空位
+func (f *Fenwick[T]) FindKth(k T) int {
+	pos := 0
+	for step := 1 << bits.Len(uint(f.Len())) >> 1; step > 0; step >>= 1 {
+		if next := pos + step; next < len(f.tree) && f.tree[next] < k {
+			pos = next
+			k -= f.tree[next]
+		}
+	}
+	return pos
+}
+
+// RangeFenwick 支持区间加、区间和的树状数组
+// 差分数组d上: nums[0:i]的和 = i*sum(d[0:i]) - sum(j*d[j])
+type RangeFenwick[T constraints.Number] struct {
+	d, id *Fenwick[T]
+}
+
+func NewRangeFenwick[T constraints.Number](n int) *RangeFenwick[T] {
+	return &RangeFenwick[T]{d: NewFenwick[T](n), id: NewFenwick[T](n)}
+}
+
+func NewRangeFenwickFrom[T constraints.Number](nums []T) *RangeFenwick[T] {
+	d := make([]T, len(nums))
+	id := make([]T, len(nums))
+	var prev T
+	for i, v := range nums {
+		d[i] = v - prev
+		id[i] = d[i] * T(i)
+		prev = v
+	}
+	return &RangeFenwick[T]{d: NewFenwickFrom(d), id: NewFenwickFrom(id)}
+}
+
+func (f *RangeFenwick[T]) Len() int {
+	return f.d.Len()
+}
+
+// RangeAdd nums[l:r]都加上delta
+func (f *RangeFenwick[T]) RangeAdd(l, r int, delta T) {
+	if l >= r {
+		return
+	}
+	f.d.Add(l, delta)
+	f.id.Add(l, delta*T(l))
+	if r < f.Len() {
+		f.d.Add(r, -delta)
+		f.id.Add(r, -delta*T(r))
+	}
+}
+
+func (f *RangeFenwick[T]) PrefixSum(i int) T {
+	return T(i)*f.d.PrefixSum(i) - f.id.PrefixSum(i)
+}
+
+func (f *RangeFenwick[T]) RangeSum(l, r int) T {
+	return f.PrefixSum(r) - f.PrefixSum(l)
+}
+
+// Get nums[i]，即差分数组的前缀和
+func (f *RangeFenwick[T]) Get(i int) T {
+	return f.d.PrefixSum(i + 1)
+}
+
+func TestFenwick() {
+	r := rand.New(rand.NewSource(44))
+	bad := map[string]int{}
+	for round := 0; round < 200; round++ {
+		n := r.Intn(50)
+		nums := make([]int, n)
+		for i := range nums {
+			nums[i] = r.Intn(5)
+		}
+		f := NewFenwickFrom(nums)
+		rf := NewRangeFenwickFrom(nums)
+		for op := 0; op < 50 && n > 0; op++ {
+			l := r.Intn(n)
+			rr := l + r.Intn(n-l+1)
+			switch r.Intn(3) {
+			case 0:
+				v := r.Intn(5)
+				f.Set(l, v)
+				rf.RangeAdd(l, l+1, v-nums[l])
+				nums[l] = v
+			case 1:
+				delta := r.Intn(3)
+				for i := l; i < rr; i++ {
+					f.Add(i, delta)
+					nums[i] += delta
+				}
+				rf.RangeAdd(l, rr, delta)
+			}
+			sum := 0
+			for _, v := range nums[l:rr] {
+				sum += v
+			}
+			if f.RangeSum(l, rr) != sum {
+				bad["Fenwick.RangeSum"]++
+			}
+			if rf.RangeSum(l, rr) != sum || rf.Get(l) != nums[l] {
+				bad["RangeFenwick"]++
+			}
+			// FindKth对照: 线性找第一个前缀和>=k的位置
+			k := r.Intn(sum + 3)
+			want, acc := n, 0
+			for i, v := range nums {
+				acc += v
+				if acc >= k {
+					want = i
+					break
+				}
+			}
+			if k > 0 && f.FindKth(k) != want {
+				bad["FindKth"]++
+			}
+		}
+	}
+	// 浮点数
+	ff := NewFenwickFrom([]float64{0.5, 1.5, 2})
+	ff.Add(1, 0.25)
+	fmt.Println(ff.RangeSum(0, 3), ff.Get(1))
+	fmt.Println("mismatches:", bad)
+}
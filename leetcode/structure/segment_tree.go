@@ -0,0 +1,206 @@
+package structure
+
+import (
+	"fmt"
+	"math/rand"
+
+	"leetcode/constraints"
+)
+
+// Aggregate 区间的和、最小值、最大值
+type Aggregate[T constraints.Number] struct {
+	Sum, Min, Max T
+	Len           int // 区间长度，为0时Sum、Min、Max无意义
+}
+
+func mergeAggregate[T constraints.Number](a, b Aggregate[T]) Aggregate[T] {
+	if a.Len == 0 {
+		return b
+	}
+	if b.Len == 0 {
+		return a
+	}
+	return Aggregate[T]{Sum: a.Sum + b.Sum, Min: min(a.Min, b.Min), Max: max(a.Max, b.Max), Len: a.Len + b.Len}
+}
+
+// SegmentTree 带懒标记的线段树，支持区间加、区间赋值，以及区间和、最小值、最大值查询
+// 所有操作O(logn)，区间均为左闭右开[l, r)
+type SegmentTree[T constraints.Number] struct {
+	n      int
+	agg    []Aggregate[T]
+	add    []T    // 子节点尚未加上的值
+	set    []T    // 子节点尚未赋的值
+	hasSet []bool // 赋值标记优先于加法标记: 先赋值再加
+}
+
+func NewSegmentTree[T constraints.Number](nums []T) *SegmentTree[T] {
+	n := len(nums)
+	t := &SegmentTree[T]{
+		n:      n,
+		agg:    make([]Aggregate[T], 4*max(n, 1)),
+		add:    make([]T, 4*max(n, 1)),
+		set:    make([]T, 4*max(n, 1)),
+		hasSet: make([]bool, 4*max(n, 1)),
+	}
+	if n > 0 {
+		t.build(1, 0, n, nums)
+	}
+	return t
+}
+
+func (t *SegmentTree[T]) Len() int {
+	return t.n
+}
+
+func (t *SegmentTree[T]) build(node, l, r int, nums []T) {
+	if r-l == 1 {
+		t.agg[node] = Aggregate[T]{Sum: nums[l], Min: nums[l], Max: nums[l], Len: 1}
+		return
+	}
+	m := (l + r) / 2
+	t.build(2*node, l, m, nums)
+	t.build(2*node+1, m, r, nums)
+	t.agg[node] = mergeAggregate(t.agg[2*node], t.agg[2*node+1])
+}
+
+func (t *SegmentTree[T]) applySet(node int, v T) {
+	a := &t.agg[node]
+	a.Sum, a.Min, a.Max = v*T(a.Len), v, v
+	t.set[node], t.hasSet[node] = v, true
+	t.add[node] = 0
+}
+
+func (t *SegmentTree[T]) applyAdd(node int, v T) {
+	a := &t.agg[node]
+	a.Sum += v * T(a.Len)
+	a.Min += v
+	a.Max += v
+	if t.hasSet[node] {
+		// 已有赋值标记时直接并入赋值
+		t.set[node] += v
+	} else {
+		t.add[node] += v
+	}
+}
+
+// push 将懒标记下传给两个子节点
+func (t *SegmentTree[T]) push(node int) {
+	if t.hasSet[node] {
+		t.applySet(2*node, t.set[node])
+		t.applySet(2*node+1, t.set[node])
+		t.hasSet[node] = false
+	}
+	if t.add[node] != 0 {
+		t.applyAdd(2*node, t.add[node])
+		t.applyAdd(2*node+1, t.add[node])
+		t.add[node] = 0
+	}
+}
+
+func (t *SegmentTree[T]) update(node, l, r, ql, qr int, v T, assign bool) {
+	if qr <= l || r <= ql {
+		return
+	}
+	if ql <= l && r <= qr {
+		if assign {
+			t.applySet(node, v)
+		} else {
+			t.applyAdd(node, v)
+		}
+		return
+	}
+	t.push(node)
+	m := (l + r) / 2
+	t.update(2*node, l, m, ql, qr, v, assign)
+	t.update(2*node+1, m, r, ql, qr, v, assign)
+	t.agg[node] = mergeAggregate(t.agg[2*node], t.agg[2*node+1])
+}
+
+// Add nums[l:r]都加上v
+func (t *SegmentTree[T]) Add(l, r int, v T) {
+	if l < r {
+		t.update(1, 0, t.n, l, r, v, false)
+	}
+}
+
+// Assign nums[l:r]都赋值为v
+func (t *SegmentTree[T]) Assign(l, r int, v T) {
+	if l < r {
+		t.update(1, 0, t.n, l, r, v, true)
+	}
+}
+
+func (t *SegmentTree[T]) query(node, l, r, ql, qr int) Aggregate[T] {
+	if qr <= l || r <= ql {
+		return Aggregate[T]{}
+	}
+	if ql <= l && r <= qr {
+		return t.agg[node]
+	}
+	t.push(node)
+	m := (l + r) / 2
+	return mergeAggregate(t.query(2*node, l, m, ql, qr), t.query(2*node+1, m, r, ql, qr))
+}
+
+// Query nums[l:r]的和、最小值、最大值
+func (t *SegmentTree[T]) Query(l, r int) Aggregate[T] {
+	if l >= r {
+		return Aggregate[T]{}
+	}
+	return t.query(1, 0, t.n, l, r)
+}
+
+func (t *SegmentTree[T]) Sum(l, r int) T {
+	return t.Query(l, r).Sum
+}
+
+// Min 要求l < r
+func (t *SegmentTree[T]) Min(l, r int) T {
+	return t.Query(l, r).Min
+}
+
+// Max 要求l < r
+func (t *SegmentTree[T]) Max(l, r int) T {
+	return t.Query(l, r).Max
+}
+
+func TestSegmentTree() {
+	r := rand.New(rand.NewSource(45))
+	bad := 0
+	for round := 0; round < 300; round++ {
+		n := 1 + r.Intn(40)
+		nums := make([]int, n)
+		for i := range nums {
+			nums[i] = r.Intn(21) - 10
+		}
+		t := NewSegmentTree(nums)
+		for op := 0; op < 60; op++ {
+			l := r.Intn(n)
+			rr := l + 1 + r.Intn(n-l)
+			v := r.Intn(21) - 10
+			switch r.Intn(3) {
+			case 0:
+				t.Add(l, rr, v)
+				for i := l; i < rr; i++ {
+					nums[i] += v
+				}
+			case 1:
+				t.Assign(l, rr, v)
+				for i := l; i < rr; i++ {
+					nums[i] = v
+				}
+			}
+			l = r.Intn(n)
+			rr = l + 1 + r.Intn(n-l)
+			sum, lo, hi := 0, nums[l], nums[l]
+			for _, x := range nums[l:rr] {
+				sum += x
+				lo, hi = min(lo, x), max(hi, x)
+			}
+			if a := t.Query(l, rr); a.Sum != sum || a.Min != lo || a.Max != hi || a.Len != rr-l {
+				bad++
+			}
+		}
+	}
+	fmt.Println("mismatches:", bad)
+}
@@ -0,0 +1,80 @@
+package structure
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"math/rand"
+)
+
+// SparseTable 静态数组上的区间查询，op需满足结合律且幂等(min、max、gcd等)
+// 预处理O(nlogn)，查询O(1): 用两个长度为2^k的区间覆盖[l, r)
+type SparseTable[T any] struct {
+	table [][]T // table[k][i] = op(nums[i:i+2^k])
+	op    func(a, b T) T
+}
+
+func NewSparseTable[T any](nums []T, op func(a, b T) T) *SparseTable[T] {
+	st := &SparseTable[T]{table: [][]T{append([]T(nil), nums...)}, op: op}
+	for k := 1; 1<<k <= len(nums); k++ {
+		prev := st.table[k-1]
+		half := 1 << (k - 1)
+		row := make([]T, len(nums)-1<<k+1)
+		for i := range row {
+			row[i] = op(prev[i], prev[i+half])
+		}
+		st.table = append(st.table, row)
+	}
+	return st
+}
+
+// NewMinSparseTable 区间最小值
+func NewMinSparseTable[T cmp.Ordered](nums []T) *SparseTable[T] {
+	return NewSparseTable(nums, func(a, b T) T { return min(a, b) })
+}
+
+// NewMaxSparseTable 区间最大值
+func NewMaxSparseTable[T cmp.Ordered](nums []T) *SparseTable[T] {
+	return NewSparseTable(nums, func(a, b T) T { return max(a, b) })
+}
+
+func (st *SparseTable[T]) Len() int {
+	return len(st.table[0])
+}
+
+// Query op(nums[l:r])，要求l < r
+func (st *SparseTable[T]) Query(l, r int) T {
+	k := bits.Len(uint(r-l)) - 1
+	return st.op(st.table[k][l], st.table[k][r-1<<k])
+}
+
+func TestSparseTable() {
+	r := rand.New(rand.NewSource(46))
+	bad := 0
+	gcd := func(a, b int) int {
+		for b != 0 {
+			a, b = b, a%b
+		}
+		return a
+	}
+	for round := 0; round < 300; round++ {
+		n := 1 + r.Intn(70)
+		nums := make([]int, n)
+		for i := range nums {
+			nums[i] = r.Intn(100) * (1 + r.Intn(3))
+		}
+		mn, mx := NewMinSparseTable(nums), NewMaxSparseTable(nums)
+		g := NewSparseTable(nums, gcd)
+		for l := 0; l < n; l++ {
+			lo, hi, d := nums[l], nums[l], nums[l]
+			for rr := l + 1; rr <= n; rr++ {
+				x := nums[rr-1]
+				lo, hi, d = min(lo, x), max(hi, x), gcd(d, x)
+				if mn.Query(l, rr) != lo || mx.Query(l, rr) != hi || g.Query(l, rr) != d {
+					bad++
+				}
+			}
+		}
+	}
+	fmt.Println("mismatches:", bad)
+}
@@ -1,44 +1,138 @@
 package structure
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
+// UnionSet 并查集，按大小合并 + 路径减半
+// 元素可以是任意整数，内部存成连续的下标；元素恰好是 0..n-1 时不需要映射，适合元素稠密且数量很大的场景
 type UnionSet struct {
-	parent map[int]int
+	parent []int
+	size   []int
+	index  map[int]int // 元素 -> 下标，为nil时元素就是下标
+	keys   []int       // 下标 -> 元素，index为nil时不使用
 }
 
 func NewUnionSet(arr []int) *UnionSet {
-	us := &UnionSet{make(map[int]int)}
-	for i := 0; i < len(arr); i++ {
-		us.parent[arr[i]] = i
+	us := NewUnionSetN(0)
+	for _, x := range arr {
+		us.Add(x)
 	}
 	return us
 }
+
+// NewUnionSetN 元素为 0..n-1 的并查集
+func NewUnionSetN(n int) *UnionSet {
+	us := &UnionSet{parent: make([]int, n), size: make([]int, n)}
+	for i := 0; i < n; i++ {
+		us.parent[i] = i
+		us.size[i] = 1
+	}
+	return us
+}
+
+// Add 加入一个独立的元素x，已经存在时返回false
+// 按 0,1,2... 的顺序加入时保持不需要映射的形式
+func (us *UnionSet) Add(x int) bool {
+	if _, ok := us.id(x); ok {
+		return false
+	}
+	i := len(us.parent)
+	if us.index == nil && x != i {
+		// 元素不再是连续的下标，改为用map映射
+		us.index = make(map[int]int, i+1)
+		us.keys = make([]int, i)
+		for k := 0; k < i; k++ {
+			us.index[k] = k
+			us.keys[k] = k
+		}
+	}
+	if us.index != nil {
+		us.index[x] = i
+		us.keys = append(us.keys, x)
+	}
+	us.parent = append(us.parent, i)
+	us.size = append(us.size, 1)
+	return true
+}
+
+// Len 元素个数
+func (us *UnionSet) Len() int {
+	return len(us.parent)
+}
+
+func (us *UnionSet) id(x int) (int, bool) {
+	if us.index == nil {
+		return x, x >= 0 && x < len(us.parent)
+	}
+	i, ok := us.index[x]
+	return i, ok
+}
+
+func (us *UnionSet) root(i int) int {
+	for us.parent[i] != i {
+		// 路径减半
+		us.parent[i] = us.parent[us.parent[i]]
+		i = us.parent[i]
+	}
+	return i
+}
+
+// Find 返回x所在集合的代表元素，x不存在时返回math.MinInt32
 func (us *UnionSet) Find(x int) int {
-	if _, ok := us.parent[x]; !ok {
+	i, ok := us.id(x)
+	if !ok {
 		return math.MinInt32
 	}
-	if x != us.parent[x] {
-		x = us.Find(us.parent[x])
+	r := us.root(i)
+	if us.index != nil {
+		return us.keys[r]
 	}
-	return us.parent[x]
+	return r
 }
 
+// Union 合并i和j所在的集合，元素不存在或原本就在同一集合时返回false
 func (us *UnionSet) Union(i, j int) bool {
-	pi := us.Find(i)
-	pj := us.Find(j)
-	if pi == pj {
-		return true
+	x, okx := us.id(i)
+	y, oky := us.id(j)
+	if !okx || !oky {
+		return false
+	}
+	rx, ry := us.root(x), us.root(y)
+	if rx == ry {
+		return false
+	}
+	if us.size[rx] < us.size[ry] {
+		rx, ry = ry, rx
 	}
-	us.parent[pi] = pj
+	us.parent[ry] = rx
+	us.size[rx] += us.size[ry]
 	return true
 }
 
 func (us *UnionSet) IsSameSet(i, j int) bool {
-	if _, ok := us.parent[i]; !ok {
-		return false
-	}
-	if _, ok := us.parent[j]; !ok {
-		return false
+	x, okx := us.id(i)
+	y, oky := us.id(j)
+	return okx && oky && us.root(x) == us.root(y)
+}
+
+// Size x所在集合的元素个数，x不存在时返回0
+func (us *UnionSet) Size(x int) int {
+	i, ok := us.id(x)
+	if !ok {
+		return 0
 	}
-	return us.Find(i) == us.Find(j)
+	return us.size[us.root(i)]
+}
+
+func TestUnionSet() {
+	us := NewUnionSetN(4)
+	fmt.Println(us.Union(0, 1), us.Union(1, 0), us.IsSameSet(0, 1), us.Size(1), us.Len())
+	us.Add(4)
+	fmt.Println(us.Union(3, 4), us.Size(4), us.Find(9))
+	// 任意整数作为元素
+	ks := NewUnionSet([]int{-5, 100, 7})
+	ks.Union(-5, 7)
+	fmt.Println(ks.IsSameSet(7, -5), ks.IsSameSet(100, 7), ks.Find(7) == ks.Find(-5), ks.Find(3) == math.MinInt32)
 }
@@ -0,0 +1,223 @@
+package window
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
+	"time"
+
+	"leetcode/constraints"
+)
+
+// Count 消费in中的元素，每个元素输出一次最近size个元素的聚合值
+// in关闭或ctx取消后输出关闭，消费者提前停止读取时应取消ctx，否则goroutine会一直阻塞
+func Count[T constraints.Number](ctx context.Context, in <-chan T, size int) <-chan Stats[T] {
+	out := make(chan Stats[T])
+	w := NewCountWindow[T](size)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- w.Push(v):
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// CountSeq 与Count相同，输入输出为迭代器
+func CountSeq[T constraints.Number](seq iter.Seq[T], size int) iter.Seq[Stats[T]] {
+	return func(yield func(Stats[T]) bool) {
+		w := NewCountWindow[T](size)
+		for v := range seq {
+			if !yield(w.Push(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Time 消费带时间戳的采样，每个采样输出一次最近span时间内的聚合值，ctx的用法与Count相同
+func Time[T constraints.Number](ctx context.Context, in <-chan Sample[T], span time.Duration) <-chan Stats[T] {
+	out := make(chan Stats[T])
+	w := NewTimeWindow[T](span)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case s, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- w.Push(s):
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// TimeSeq 与Time相同，输入输出为迭代器
+func TimeSeq[T constraints.Number](seq iter.Seq[Sample[T]], span time.Duration) iter.Seq[Stats[T]] {
+	return func(yield func(Stats[T]) bool) {
+		w := NewTimeWindow[T](span)
+		for s := range seq {
+			if !yield(w.Push(s)) {
+				return
+			}
+		}
+	}
+}
+
+// bruteStats 直接遍历求聚合值
+func bruteStats[T constraints.Number](vals []T) Stats[T] {
+	if len(vals) == 0 {
+		return Stats[T]{}
+	}
+	st := Stats[T]{Max: vals[0], Min: vals[0], Count: len(vals)}
+	for _, v := range vals {
+		st.Max, st.Min = max(st.Max, v), min(st.Min, v)
+		st.Sum += v
+	}
+	st.Avg = float64(st.Sum) / float64(len(vals))
+	return st
+}
+
+func TestWindow() {
+	r := rand.New(rand.NewSource(7))
+	bad := 0
+	for round := 0; round < 300; round++ {
+		nums := make([]int, r.Intn(50))
+		for i := range nums {
+			nums[i] = r.Intn(100) - 50
+		}
+		size := 1 + r.Intn(8)
+
+		// 迭代器与channel两种输入
+		i := 0
+		for st := range CountSeq(slices.Values(nums), size) {
+			if st != bruteStats(nums[max(0, i-size+1):i+1]) {
+				bad++
+			}
+			i++
+		}
+		in := make(chan int)
+		go func() {
+			for _, v := range nums {
+				in <- v
+			}
+			close(in)
+		}()
+		i = 0
+		for st := range Count(context.Background(), in, size) {
+			if st != bruteStats(nums[max(0, i-size+1):i+1]) {
+				bad++
+			}
+			i++
+		}
+
+		// 时间窗口
+		base := time.Unix(0, 0)
+		span := time.Duration(1+r.Intn(10)) * time.Second
+		samples := make([]Sample[int], len(nums))
+		t := base
+		for i, v := range nums {
+			t = t.Add(time.Duration(r.Intn(3)) * time.Second)
+			samples[i] = Sample[int]{Time: t, Value: v}
+		}
+		i = 0
+		for st := range TimeSeq(slices.Values(samples), span) {
+			var live []int
+			for _, s := range samples[:i+1] {
+				if s.Time.After(samples[i].Time.Add(-span)) {
+					live = append(live, s.Value)
+				}
+			}
+			if st != bruteStats(live) {
+				bad++
+			}
+			i++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+
+	// 消费者提前停止读取: 取消ctx后输出关闭，goroutine退出
+	before := runtime.NumGoroutine()
+	for range 100 {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan int)
+		go func() {
+			defer close(in)
+			for v := 0; ; v++ {
+				select {
+				case in <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		out := Count(ctx, in, 3)
+		<-out
+		cancel()
+		for range out {
+		}
+		ts := make(chan Sample[int], 1)
+		ts <- Sample[int]{Value: 1}
+		ctx, cancel = context.WithCancel(context.Background())
+		out = Time(ctx, ts, time.Second)
+		cancel()
+		for range out {
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	fmt.Println("leaked goroutines:", runtime.NumGoroutine()-before)
+
+	// 浮点数: 大小悬殊的值混在一起，运行很久之后Sum和Avg仍应与窗口内容一致
+	floats := make([]float64, 200000)
+	for i := range floats {
+		if r.Intn(10) == 0 {
+			floats[i] = (r.Float64() - 0.5) * 1e16
+		} else {
+			floats[i] = r.Float64()
+		}
+	}
+	drift, i := 0.0, 0
+	for st := range CountSeq(slices.Values(floats), 5) {
+		win := floats[max(0, i-4) : i+1]
+		want, scale := 0.0, 0.0
+		for _, v := range win {
+			want += v
+			scale += math.Abs(v)
+		}
+		drift = max(drift, math.Abs(st.Sum-want)/scale)
+		i++
+	}
+	fmt.Printf("float relative drift: %.3g\n", drift)
+
+	w := NewTimeWindow[float64](time.Minute)
+	now := time.Now()
+	w.Push(Sample[float64]{Time: now, Value: 1.5})
+	w.Push(Sample[float64]{Time: now.Add(30 * time.Second), Value: 3})
+	fmt.Printf("%+v\n", w.Stats())
+	fmt.Printf("%+v\n", w.Advance(now.Add(80*time.Second)))
+	fmt.Printf("%+v\n", w.Advance(now.Add(2*time.Minute)))
+}
@@ -0,0 +1,311 @@
+package window
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"leetcode/constraints"
+	"leetcode/mono"
+)
+
+// SubarraySum 流式统计和为k的连续子数组个数，与subarraySum2一样用前缀和+哈希表
+// 第e个元素(从0开始)到达时，以它结尾、和为k的子数组个数等于前缀和P[e+1]-k出现的次数
+// window > 0 时只统计长度不超过window的子数组，更早的前缀和会被淘汰，内存为O(window)
+// 前缀和需要精确相等，只支持整数；前缀和与k统一用int64保存，int8等窄类型的前缀和不会回绕；
+// 只有int64、uint64这样的宽类型在前缀和超出int64范围时才会回绕
+type SubarraySum[T constraints.Integer] struct {
+	k      int64
+	window int
+	n      int   // 已经到达的元素个数
+	sum    int64 // 当前前缀和P[n]
+	total  int64
+	count  map[int64]int // 前缀和 -> 出现次数
+	recent *mono.Deque[int64]
+
+	track     bool
+	positions map[int64]*mono.Deque[int] // 前缀和 -> 出现的位置j(P[j])，递增
+	target    int64                      // 最近一个元素匹配的前缀和
+}
+
+// NewSubarraySum window <= 0 表示不限制子数组长度
+// track为true时记录每个前缀和出现的位置，可以通过LastMatches取得子数组的起止下标
+func NewSubarraySum[T constraints.Integer](k T, window int, track bool) *SubarraySum[T] {
+	c := &SubarraySum[T]{
+		k:      int64(k),
+		window: window,
+		count:  map[int64]int{},
+		recent: mono.NewDeque[int64](16),
+		track:  track,
+	}
+	if track {
+		c.positions = map[int64]*mono.Deque[int]{}
+	}
+	c.insert(0)
+	return c
+}
+
+// insert 记录前缀和P[n]
+func (c *SubarraySum[T]) insert(p int64) {
+	c.count[p]++
+	if c.window > 0 {
+		c.recent.PushBack(p)
+	}
+	if c.track {
+		d := c.positions[p]
+		if d == nil {
+			d = mono.NewDeque[int](1)
+			c.positions[p] = d
+		}
+		d.PushBack(c.n)
+	}
+}
+
+// evict 淘汰最早的前缀和
+func (c *SubarraySum[T]) evict() {
+	p := c.recent.PopFront()
+	if c.count[p]--; c.count[p] == 0 {
+		delete(c.count, p)
+	}
+	if c.track {
+		d := c.positions[p]
+		d.PopFront()
+		if d.Empty() {
+			delete(c.positions, p)
+		}
+	}
+}
+
+// Push 加入一个元素，返回以它结尾、和为k的子数组个数
+func (c *SubarraySum[T]) Push(v T) int {
+	// 第n个元素只能与P[n+1-window..n]匹配，共window个前缀和
+	for c.window > 0 && c.recent.Len() > c.window {
+		c.evict()
+	}
+	c.sum += int64(v)
+	c.target = c.sum - c.k
+	matches := c.count[c.target]
+	c.total += int64(matches)
+	c.n++
+	c.insert(c.sum)
+	return matches
+}
+
+// Count 到目前为止和为k的子数组总数
+func (c *SubarraySum[T]) Count() int64 {
+	return c.total
+}
+
+// Len 已经到达的元素个数
+func (c *SubarraySum[T]) Len() int {
+	return c.n
+}
+
+// LastMatches 以最近一个元素结尾、和为k的子数组，产出起止下标(闭区间)，起点递增
+// 需要构造时track为true
+func (c *SubarraySum[T]) LastMatches() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		if !c.track || c.n == 0 {
+			return
+		}
+		d := c.positions[c.target]
+		if d == nil {
+			return
+		}
+		end := c.n - 1
+		for i := 0; i < d.Len(); i++ {
+			// k为0时刚插入的P[n]也等于target，它不对应任何子数组
+			if j := d.At(i); j <= end {
+				if !yield(j, end) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Consume 从r中读取以空白分隔的整数逐个加入，visit不为nil时每个元素调用一次
+func (c *SubarraySum[T]) Consume(r io.Reader, visit func(matches int)) error {
+	for v, err := range ReadIntegers[T](r) {
+		if err != nil {
+			return err
+		}
+		m := c.Push(v)
+		if visit != nil {
+			visit(m)
+		}
+	}
+	return nil
+}
+
+// ReadIntegers 逐个读取以空白分隔的整数，解析失败时产出错误并结束
+func ReadIntegers[T constraints.Integer](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		sc := bufio.NewScanner(r)
+		sc.Split(bufio.ScanWords)
+		for sc.Scan() {
+			var v T
+			// 先按有符号数解析，失败再按无符号数，超出T的范围视为错误
+			if x, err := strconv.ParseInt(sc.Text(), 10, 64); err == nil && int64(T(x)) == x && (T(x) < 0) == (x < 0) {
+				v = T(x)
+			} else if u, err := strconv.ParseUint(sc.Text(), 10, 64); err == nil && uint64(T(u)) == u && T(u) >= 0 {
+				v = T(u)
+			} else {
+				yield(0, fmt.Errorf("window: invalid number %q", sc.Text()))
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err := sc.Err(); err != nil {
+			yield(0, err)
+		}
+	}
+}
+
+// SubarraySums 消费in中的元素，每个元素输出一次当前的累计个数
+// in关闭或ctx取消后输出关闭，与Count一样，消费者提前停止读取时应取消ctx
+func SubarraySums[T constraints.Integer](ctx context.Context, in <-chan T, k T, window int) <-chan int64 {
+	out := make(chan int64)
+	c := NewSubarraySum(k, window, false)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				c.Push(v)
+				select {
+				case out <- c.Count():
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// bruteSubarrays 枚举所有长度不超过window、和为k的子数组
+func bruteSubarrays(nums []int, k, window int) [][2]int {
+	var res [][2]int
+	for end := range nums {
+		for start := 0; start <= end; start++ {
+			if window > 0 && end-start >= window {
+				continue
+			}
+			sum := 0
+			for _, v := range nums[start : end+1] {
+				sum += v
+			}
+			if sum == k {
+				res = append(res, [2]int{start, end})
+			}
+		}
+	}
+	return res
+}
+
+func TestSubarraySum() {
+	r := rand.New(rand.NewSource(45))
+	bad := 0
+	for round := 0; round < 500; round++ {
+		nums := make([]int, r.Intn(40))
+		for i := range nums {
+			nums[i] = r.Intn(7) - 3
+		}
+		k := r.Intn(7) - 3
+		window := r.Intn(6) // 0为不限制
+		want := bruteSubarrays(nums, k, window)
+		c := NewSubarraySum(k, window, true)
+		var got [][2]int
+		for _, v := range nums {
+			m := c.Push(v)
+			n := 0
+			for start, end := range c.LastMatches() {
+				got = append(got, [2]int{start, end})
+				n++
+			}
+			if n != m {
+				bad++
+			}
+		}
+		if len(got) != len(want) || c.Count() != int64(len(want)) {
+			bad++
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				bad++
+				break
+			}
+		}
+	}
+	// int8的整个取值范围: 前缀和很快超出int8，不能回绕后误判
+	for round := 0; round < 300; round++ {
+		nums := make([]int8, r.Intn(40))
+		ints := make([]int, len(nums))
+		for i := range nums {
+			nums[i] = int8(r.Intn(256) - 128)
+			ints[i] = int(nums[i])
+		}
+		k := int8(r.Intn(256) - 128)
+		window := r.Intn(6)
+		c := NewSubarraySum(k, window, false)
+		for _, v := range nums {
+			c.Push(v)
+		}
+		if c.Count() != int64(len(bruteSubarrays(ints, int(k), window))) {
+			bad++
+		}
+	}
+	fmt.Println("mismatches:", bad)
+
+	// 从Reader读取，与subarraySum2的结果一致: [1,1,1], k=2 -> 2
+	c := NewSubarraySum(2, 0, false)
+	err := c.Consume(strings.NewReader("1 1\n1"), nil)
+	fmt.Println(c.Count(), err)
+	c8 := NewSubarraySum[int8](0, 0, false)
+	fmt.Println(c8.Consume(strings.NewReader("1 -1 300"), nil), c8.Count())
+
+	// 从channel读取，窗口为3
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for _, v := range []int{1, 2, 3, 0, 3, -3, 3} {
+			in <- v
+		}
+	}()
+	for total := range SubarraySums(context.Background(), in, 3, 3) {
+		fmt.Print(total, " ")
+	}
+	fmt.Println()
+
+	// 提前停止读取后取消ctx，goroutine退出
+	before := runtime.NumGoroutine()
+	for range 100 {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan int, 2)
+		in <- 3
+		in <- 0
+		out := SubarraySums(ctx, in, 3, 0)
+		<-out
+		cancel()
+		for range out {
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	fmt.Println("leaked goroutines:", runtime.NumGoroutine()-before)
+}
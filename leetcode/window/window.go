@@ -0,0 +1,162 @@
+package window
+
+import (
+	"time"
+
+	"leetcode/constraints"
+	"leetcode/mono"
+)
+
+// Stats 窗口内的聚合值，Count为0时其余字段无意义
+type Stats[T constraints.Number] struct {
+	Max   T
+	Min   T
+	Sum   T
+	Count int
+	Avg   float64
+}
+
+// Sample 带时间戳的采样值
+type Sample[T constraints.Number] struct {
+	Time  time.Time
+	Value T
+}
+
+type entry[T constraints.Number] struct {
+	seq   int
+	value T
+}
+
+// aggregator 窗口中元素按到达顺序编号，最大最小值用单调队列维护，过期时按编号淘汰
+type aggregator[T constraints.Number] struct {
+	maxQ *mono.MonoQueue[entry[T]]
+	minQ *mono.MonoQueue[entry[T]]
+	sum  T
+	comp T   // 浮点求和的补偿项，整数类型恒为0
+	head int // 窗口中最早元素的编号
+	next int // 下一个元素的编号
+}
+
+func newAggregator[T constraints.Number]() *aggregator[T] {
+	return &aggregator[T]{
+		maxQ: mono.NewMonoQueue(func(a, b entry[T]) bool { return a.value < b.value }),
+		minQ: mono.NewMonoQueue(func(a, b entry[T]) bool { return a.value > b.value }),
+	}
+}
+
+func (a *aggregator[T]) push(v T) {
+	e := entry[T]{seq: a.next, value: v}
+	a.next++
+	a.maxQ.Push(e)
+	a.minQ.Push(e)
+	a.add(v)
+}
+
+// add Neumaier补偿求和: comp记录每次加法舍去的低位，浮点数反复加减大小悬殊的值也不会漂移
+// 整数类型的加减是精确的(回绕也一样)，comp始终为0
+func (a *aggregator[T]) add(v T) {
+	s := a.sum + v
+	if abs(a.sum) >= abs(v) {
+		a.comp += (a.sum - s) + v
+	} else {
+		a.comp += (v - s) + a.sum
+	}
+	a.sum = s
+}
+
+func abs[T constraints.Number](v T) T {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// pop 淘汰最早的元素v
+func (a *aggregator[T]) pop(v T) {
+	a.head++
+	if a.head == a.next {
+		a.sum, a.comp = 0, 0 // 窗口清空时丢掉累积的误差
+	} else {
+		a.add(-v)
+	}
+	expired := func(e entry[T]) bool { return e.seq < a.head }
+	a.maxQ.Evict(expired)
+	a.minQ.Evict(expired)
+}
+
+func (a *aggregator[T]) stats() Stats[T] {
+	n := a.next - a.head
+	if n == 0 {
+		return Stats[T]{}
+	}
+	return Stats[T]{
+		Max:   a.maxQ.Front().value,
+		Min:   a.minQ.Front().value,
+		Sum:   a.sum + a.comp,
+		Count: n,
+		Avg:   float64(a.sum+a.comp) / float64(n),
+	}
+}
+
+// CountWindow 最近size个元素的滑动窗口，每个元素均摊O(1)
+type CountWindow[T constraints.Number] struct {
+	size   int
+	values *mono.Deque[T]
+	agg    *aggregator[T]
+}
+
+func NewCountWindow[T constraints.Number](size int) *CountWindow[T] {
+	if size < 1 {
+		panic("window size must be positive")
+	}
+	return &CountWindow[T]{size: size, values: mono.NewDeque[T](size), agg: newAggregator[T]()}
+}
+
+// Push 加入新元素，返回加入后窗口的聚合值
+func (w *CountWindow[T]) Push(v T) Stats[T] {
+	w.values.PushBack(v)
+	w.agg.push(v)
+	if w.values.Len() > w.size {
+		w.agg.pop(w.values.PopFront())
+	}
+	return w.agg.stats()
+}
+
+func (w *CountWindow[T]) Stats() Stats[T] {
+	return w.agg.stats()
+}
+
+// TimeWindow 时间跨度为span的滑动窗口，包含时间在 (now-span, now] 内的元素
+// 采样需按时间非递减的顺序到达
+type TimeWindow[T constraints.Number] struct {
+	span    time.Duration
+	samples *mono.Deque[Sample[T]]
+	agg     *aggregator[T]
+}
+
+func NewTimeWindow[T constraints.Number](span time.Duration) *TimeWindow[T] {
+	if span <= 0 {
+		panic("window span must be positive")
+	}
+	return &TimeWindow[T]{span: span, samples: mono.NewDeque[Sample[T]](8), agg: newAggregator[T]()}
+}
+
+// Push 加入新采样，以采样时间为当前时间淘汰过期元素
+func (w *TimeWindow[T]) Push(s Sample[T]) Stats[T] {
+	w.samples.PushBack(s)
+	w.agg.push(s.Value)
+	return w.Advance(s.Time)
+}
+
+// Advance 没有新采样时推进当前时间，淘汰过期元素
+func (w *TimeWindow[T]) Advance(now time.Time) Stats[T] {
+	cutoff := now.Add(-w.span)
+	for !w.samples.Empty() && !w.samples.Front().Time.After(cutoff) {
+		w.agg.pop(w.samples.PopFront().Value)
+	}
+	return w.agg.stats()
+}
+
+func (w *TimeWindow[T]) Stats() Stats[T] {
+	return w.agg.stats()
+}
@@ -0,0 +1,138 @@
+package wordseg
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// bruteAll 递归枚举所有切分，每个位置遍历整个词典
+func bruteAll(s string, words []string) [][]string {
+	if s == "" {
+		return [][]string{{}}
+	}
+	var res [][]string
+	for _, w := range words {
+		if strings.HasPrefix(s, w) {
+			for _, rest := range bruteAll(s[len(w):], words) {
+				res = append(res, append([]string{w}, rest...))
+			}
+		}
+	}
+	return res
+}
+
+func TestWordSeg() {
+	r := rand.New(rand.NewSource(49))
+	bad := map[string]int{}
+	for round := 0; round < 500; round++ {
+		// 小字母表、短词，切分方式很多
+		var words []string
+		for k := 1 + r.Intn(6); k > 0; k-- {
+			var sb strings.Builder
+			for n := 1 + r.Intn(3); n > 0; n-- {
+				sb.WriteByte(byte('a' + r.Intn(2)))
+			}
+			if !slices.Contains(words, sb.String()) {
+				words = append(words, sb.String())
+			}
+		}
+		var sb strings.Builder
+		for n := 1 + r.Intn(12); n > 0; n-- {
+			sb.WriteByte(byte('a' + r.Intn(2)))
+		}
+		s := sb.String()
+
+		freq := map[string]float64{}
+		for _, w := range words {
+			freq[w] = float64(1 + r.Intn(10))
+		}
+		d := NewDictFromFreq(freq)
+		want := bruteAll(s, words)
+		var got [][]string
+		for seg := range d.All(s) {
+			got = append(got, slices.Clone(seg))
+		}
+		key := func(segs [][]string) []string {
+			res := make([]string, len(segs))
+			for i, seg := range segs {
+				res[i] = Join(seg)
+			}
+			slices.Sort(res)
+			return res
+		}
+		if !slices.Equal(key(got), key(want)) {
+			bad["All"]++
+		}
+		if d.CanSegment(s) != (len(want) > 0) {
+			bad["CanSegment"]++
+		}
+		// 与暴力枚举的最少词数、最大概率比较
+		minWords, maxProb := math.MaxInt, math.Inf(-1)
+		for _, seg := range want {
+			minWords = min(minWords, len(seg))
+			p := 0.0
+			for _, w := range seg {
+				p += math.Log(freq[w] / d.total)
+			}
+			maxProb = max(maxProb, p)
+		}
+		if seg, ok := d.MinWords(s); ok != (len(want) > 0) || (ok && (len(seg) != minWords || strings.Join(seg, "") != s)) {
+			bad["MinWords"]++
+		}
+		if seg, ok := d.MaxProb(s); ok != (len(want) > 0) || (ok && strings.Join(seg, "") != s) {
+			bad["MaxProb"]++
+		} else if ok {
+			p := 0.0
+			for _, w := range seg {
+				p += math.Log(freq[w] / d.total)
+			}
+			if math.Abs(p-maxProb) > 1e-9 {
+				bad["MaxProb"]++
+			}
+		}
+		// 提前停止
+		n := 0
+		for range d.All(s) {
+			n++
+			break
+		}
+		if n != min(1, len(want)) {
+			bad["All-break"]++
+		}
+	}
+	// 最小词频的词被加大词频后，未登录字应按新的最小词频估计
+	m := NewDict()
+	m.Add("a", 1)
+	m.Add("b", 5)
+	m.Add("a", 10)
+	if m.min != 5 {
+		bad["minFreq"]++
+	}
+	m.Add("c", 2)
+	m.Add("d", 2)
+	m.Add("c", 1)
+	if m.min != 2 {
+		bad["minFreq"]++
+	}
+	m.Add("d", 5)
+	if m.min != 3 {
+		bad["minFreq"]++
+	}
+	fmt.Println("mismatches:", bad)
+
+	d := NewDictFromWords([]string{"cat", "cats", "and", "sand", "dog"})
+	for seg := range d.All("catsanddog") {
+		fmt.Println(Join(seg))
+	}
+
+	zh := NewDictFromFreq(map[string]float64{
+		"南京": 20, "南京市": 15, "市长": 30, "长江": 25, "大桥": 20,
+		"长江大桥": 10, "江大桥": 1, "笔记": 10, "整理": 8, "中文": 12, "分词": 6,
+	})
+	fmt.Println(zh.MaxProb("南京市长江大桥"))
+	fmt.Println(zh.MinWords("南京市长江大桥"))
+	fmt.Printf("%q\n", zh.Tokenize("整理Go笔记：南京市长江大桥, 中文分词 v2 测试"))
+}
@@ -0,0 +1,133 @@
+package wordseg
+
+import "math"
+
+// node 按rune分支的字典树节点，支持中文等任意Unicode字符
+type node struct {
+	children map[rune]*node
+	word     bool
+	freq     float64
+}
+
+// Dict 带词频的词典
+type Dict struct {
+	root  *node
+	total float64 // 所有词的词频之和
+	min   float64 // 最小的词频，未登录字按它估计
+	nmin  int     // 词频等于min的词的个数
+	size  int
+}
+
+func NewDict() *Dict {
+	return &Dict{root: &node{}}
+}
+
+// NewDictFromWords 不带词频的词典，每个词的词频都为1
+func NewDictFromWords(words []string) *Dict {
+	d := NewDict()
+	for _, w := range words {
+		d.Add(w, 1)
+	}
+	return d
+}
+
+// NewDictFromFreq 由 词 -> 词频 构造
+func NewDictFromFreq(freq map[string]float64) *Dict {
+	d := NewDict()
+	for w, f := range freq {
+		d.Add(w, f)
+	}
+	return d
+}
+
+// Add 加入一个词，已存在时累加词频，freq <= 0 按1处理
+func (d *Dict) Add(word string, freq float64) {
+	if word == "" {
+		return
+	}
+	if freq <= 0 {
+		freq = 1
+	}
+	cur := d.root
+	for _, r := range word {
+		next := cur.children[r]
+		if next == nil {
+			if cur.children == nil {
+				cur.children = map[rune]*node{}
+			}
+			next = &node{}
+			cur.children[r] = next
+		}
+		cur = next
+	}
+	old := cur.freq
+	cur.freq += freq
+	d.total += freq
+	switch {
+	case !cur.word:
+		cur.word = true
+		d.size++
+		if d.min == 0 || cur.freq < d.min {
+			d.min, d.nmin = cur.freq, 1
+		} else if cur.freq == d.min {
+			d.nmin++
+		}
+	case old == d.min:
+		// 最小词频的词变大了，没有其他词与它并列时重新遍历字典树
+		if d.nmin--; d.nmin == 0 {
+			d.updateMin()
+		}
+	}
+}
+
+// updateMin 遍历字典树重新计算最小词频及其个数，只在Add中调用，查询时不写Dict
+func (d *Dict) updateMin() {
+	d.min, d.nmin = 0, 0
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n.word {
+			if d.min == 0 || n.freq < d.min {
+				d.min, d.nmin = n.freq, 1
+			} else if n.freq == d.min {
+				d.nmin++
+			}
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(d.root)
+}
+
+func (d *Dict) Len() int {
+	return d.size
+}
+
+func (d *Dict) Contains(word string) bool {
+	cur := d.root
+	for _, r := range word {
+		if cur = cur.children[r]; cur == nil {
+			return false
+		}
+	}
+	return cur.word
+}
+
+// matches 从text[i]开始沿字典树走，对每个词典中的词调用f(结束位置, 词频)
+// 只走一遍字典树，不用像wordBreak那样在每个位置遍历整个词典
+func (d *Dict) matches(text []rune, i int, f func(end int, freq float64)) {
+	cur := d.root
+	for j := i; j < len(text); j++ {
+		if cur = cur.children[text[j]]; cur == nil {
+			return
+		}
+		if cur.word {
+			f(j+1, cur.freq)
+		}
+	}
+}
+
+// logProb 词频对应的对数概率
+func (d *Dict) logProb(freq float64) float64 {
+	return math.Log(freq / d.total)
+}
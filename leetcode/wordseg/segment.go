@@ -0,0 +1,162 @@
+package wordseg
+
+import (
+	"iter"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// reachable reach[i]表示text[i:]能否完全切分成词典中的词，从后往前DP
+func (d *Dict) reachable(text []rune) []bool {
+	reach := make([]bool, len(text)+1)
+	reach[len(text)] = true
+	for i := len(text) - 1; i >= 0; i-- {
+		d.matches(text, i, func(end int, _ float64) {
+			reach[i] = reach[i] || reach[end]
+		})
+	}
+	return reach
+}
+
+// CanSegment s能否完全切分成词典中的词(139)
+func (d *Dict) CanSegment(s string) bool {
+	return d.reachable([]rune(s))[0]
+}
+
+// All 惰性产出所有切分方式(140)，按第一个词从短到长的顺序
+// 先算出每个位置之后能否切分完，DFS只走能到达结尾的分支，每产出一个结果的代价与其长度成正比
+// 产出的切片在下一次迭代时会被复用，需要保留时请复制
+func (d *Dict) All(s string) iter.Seq[[]string] {
+	return func(yield func([]string) bool) {
+		text := []rune(s)
+		if len(text) == 0 {
+			return
+		}
+		reach := d.reachable(text)
+		var path []string
+		var dfs func(i int) bool
+		dfs = func(i int) bool {
+			if i == len(text) {
+				return yield(path)
+			}
+			var ends []int
+			d.matches(text, i, func(end int, _ float64) {
+				if reach[end] {
+					ends = append(ends, end)
+				}
+			})
+			for _, end := range ends {
+				path = append(path, string(text[i:end]))
+				ok := dfs(end)
+				path = path[:len(path)-1]
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		dfs(0)
+	}
+}
+
+// MinWords 词数最少的切分，无法切分时返回false
+func (d *Dict) MinWords(s string) ([]string, bool) {
+	text := []rune(s)
+	const inf = math.MaxInt
+	// best[i]: text[i:]最少切成几个词，next[i]为第一个词的结束位置
+	best := make([]int, len(text)+1)
+	next := make([]int, len(text)+1)
+	for i := len(text) - 1; i >= 0; i-- {
+		best[i] = inf
+		d.matches(text, i, func(end int, _ float64) {
+			if best[end] != inf && best[end]+1 < best[i] {
+				best[i], next[i] = best[end]+1, end
+			}
+		})
+	}
+	if best[0] == inf {
+		return nil, false
+	}
+	return collect(text, next), true
+}
+
+// MaxProb 概率最大的切分: 每个词的概率为 词频/总词频，取对数后求和最大
+// 无法只用词典中的词切分时返回false
+func (d *Dict) MaxProb(s string) ([]string, bool) {
+	text := []rune(s)
+	next, ok := d.maxProb(text, false)
+	if !ok {
+		return nil, false
+	}
+	return collect(text, next), true
+}
+
+// maxProb 从后往前DP，fallback为true时未登录的单字按最小词频计，总能切分
+func (d *Dict) maxProb(text []rune, fallback bool) ([]int, bool) {
+	best := make([]float64, len(text)+1)
+	next := make([]int, len(text)+1)
+	unknown := 0.0 // 空词典时全部按单字切
+	if d.total > 0 {
+		unknown = d.logProb(d.min)
+	}
+	for i := len(text) - 1; i >= 0; i-- {
+		best[i] = math.Inf(-1)
+		if fallback {
+			best[i], next[i] = unknown+best[i+1], i+1
+		}
+		d.matches(text, i, func(end int, freq float64) {
+			if v := d.logProb(freq) + best[end]; v > best[i] {
+				best[i], next[i] = v, end
+			}
+		})
+	}
+	return next, !math.IsInf(best[0], -1)
+}
+
+func collect(text []rune, next []int) []string {
+	var res []string
+	for i := 0; i < len(text); i = next[i] {
+		res = append(res, string(text[i:next[i]]))
+	}
+	return res
+}
+
+// Tokenize 切分一段混合文本: 汉字按最大概率切分，未登录字单独成词；
+// 连续的字母数字作为一个词；空白丢弃；其他字符(标点等)各自成为一个词
+func (d *Dict) Tokenize(s string) []string {
+	var res []string
+	text := []rune(s)
+	for i := 0; i < len(text); {
+		r := text[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.Is(unicode.Han, r):
+			j := i
+			for j < len(text) && unicode.Is(unicode.Han, text[j]) {
+				j++
+			}
+			// 汉字段按词典切，必要时退化为单字
+			next, _ := d.maxProb(text[i:j], true)
+			res = append(res, collect(text[i:j], next)...)
+			i = j
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			j := i
+			for j < len(text) && !unicode.Is(unicode.Han, text[j]) && (unicode.IsLetter(text[j]) || unicode.IsNumber(text[j])) {
+				j++
+			}
+			res = append(res, string(text[i:j]))
+			i = j
+		default:
+			res = append(res, string(r))
+			i++
+		}
+	}
+	return res
+}
+
+// Join 用空格连接一种切分，与140的输出格式相同
+func Join(words []string) string {
+	return strings.Join(words, " ")
+}